@@ -1,6 +1,10 @@
 package api
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
 
 var (
 	ErrSourceInputUndefined     = errors.New("source input undefined")
@@ -10,44 +14,88 @@ var (
 	ErrSinkEmpty                = errors.New("sink is empty")
 	ErrInputChannelUndefined    = errors.New("undefined input channel")
 	ErrSourceUndefined          = errors.New("source undefined")
+	ErrNumericOverflow          = errors.New("numeric overflow")
 )
 
-// // StreamError is used to signal runtime stream error
-// type StreamError[T any] struct {
-// 	err  string        // Error message
-// 	item StreamItem[T] // Item that caused error
-// }
-
-// // Error returns a string value for StreamError
-// func (e StreamError[T]) Error() string {
-// 	return e.err
-// }
-
-// // Item returns the StreamItem associated with the error
-// func (e StreamError[T]) Item() StreamItem[T] {
-// 	return e.item
-// }
-
-// // Error returns a StreamError
-// func Error(msg string) StreamError[any] {
-// 	return StreamError[any]{err: msg}
-// }
-
-// // ErrorWithItem returns a StreamError with provided StreamItem
-// func ErrorWithItem[T any](msg string, item StreamItem[T]) StreamError[T] {
-// 	return StreamError[T]{err: msg, item: item}
-// }
-
-// // CancelStreamError signals that all stream activities should stop
-// // and the streaming should gracefully end
-// type CancelStreamError[T any] StreamError[T]
-
-// // Error returns a string value for CancelStreamError
-// func (e CancelStreamError[T]) Error() string {
-// 	return e.err
-// }
-
-// // CancellationError returns a CancelStreamError
-// func CancellationError(msg string) CancelStreamError[any] {
-// 	return CancelStreamError[any](Error(msg))
-// }
+// StreamError is used to signal runtime stream error
+// associated with a stream item.
+type StreamError[T any] struct {
+	err  string        // Error message
+	item StreamItem[T] // Item that caused error
+}
+
+// Error returns a string value for StreamError
+func (e StreamError[T]) Error() string {
+	return e.err
+}
+
+// Item returns the StreamItem associated with the error
+func (e StreamError[T]) Item() StreamItem[T] {
+	return e.item
+}
+
+func (e StreamError[T]) streamError() {}
+
+// Error returns a StreamError
+func Error(msg string) StreamError[any] {
+	return StreamError[any]{err: msg}
+}
+
+// ErrorWithItem returns a StreamError with provided StreamItem
+func ErrorWithItem[T any](msg string, item StreamItem[T]) StreamError[T] {
+	return StreamError[T]{err: msg, item: item}
+}
+
+// CancelStreamError signals that all stream activities should stop
+// and the streaming should gracefully end
+type CancelStreamError[T any] StreamError[T]
+
+// Error returns a string value for CancelStreamError
+func (e CancelStreamError[T]) Error() string {
+	return e.err
+}
+
+// Item returns the StreamItem associated with the error
+func (e CancelStreamError[T]) Item() StreamItem[T] {
+	return e.item
+}
+
+func (e CancelStreamError[T]) cancelStreamError() {}
+
+// CancellationError returns a CancelStreamError
+func CancellationError(msg string) CancelStreamError[any] {
+	return CancelStreamError[any](Error(msg))
+}
+
+// IsStreamError returns true if err, or any error it wraps,
+// is a StreamError of any item type.
+func IsStreamError(err error) bool {
+	var target interface{ streamError() }
+	return errors.As(err, &target)
+}
+
+// IsCancelStreamError returns true if err, or any error it wraps,
+// is a CancelStreamError of any item type.
+func IsCancelStreamError(err error) bool {
+	var target interface{ cancelStreamError() }
+	return errors.As(err, &target)
+}
+
+// PanicError is used to report a panic recovered from a user-defined
+// function along with the item being processed when the panic occurred.
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack trace captured at recovery
+	Item  any    // Item being processed
+}
+
+// NewPanicError returns a *PanicError for a recovered value. It must be called
+// from the deferred function that recovered the panic to capture the stack.
+func NewPanicError(value any, item any) *PanicError {
+	return &PanicError{Value: value, Stack: debug.Stack(), Item: item}
+}
+
+// Error returns a string value for PanicError
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered panic: %v", e.Value)
+}
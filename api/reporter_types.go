@@ -22,3 +22,23 @@ type StreamLogFunc func(context.Context, StreamLog)
 type Reporter interface {
 	SetLogFunc(StreamLogFunc)
 }
+
+// StreamErrFunc defines a function that is called to propagate runtime errors
+// from stream nodes to the stream orchestrator.
+type StreamErrFunc func(context.Context, error)
+
+// ErrReporter is a stream node that can report runtime errors to the stream
+// orchestrator which decides how the stream should proceed.
+type ErrReporter interface {
+	SetErrFunc(StreamErrFunc)
+}
+
+// DeadLetterFunc defines a function that is called by stream nodes
+// to send rejected items to the stream dead-letter output.
+type DeadLetterFunc func(context.Context, DeadLetter)
+
+// DeadLetterReporter is a stream node that can send rejected items
+// to the stream dead-letter output.
+type DeadLetterReporter interface {
+	SetDeadLetterFunc(DeadLetterFunc)
+}
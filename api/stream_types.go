@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 )
 
 // Emitter is a node that has the ability to emit data to an output channel
@@ -9,6 +11,12 @@ type Emitter interface {
 	GetOutput() <-chan any
 }
 
+// SideEmitter is a node that can emit items to named side outputs
+// in addition to its main output channel.
+type SideEmitter interface {
+	GetSideOutput(name string) <-chan any
+}
+
 // Source is a component that has data that can be placed on the stream
 type Source interface {
 	Emitter
@@ -59,9 +67,47 @@ type StreamAction uint8
 const (
 	ActionForwardItem StreamAction = iota // Forward items to down the stream (default)
 	ActionSkipItem                        // Drops items from the stream
-	ActionRerouteItem                     // Reroutes item to another stream
+	ActionRerouteItem                     // Reroutes item to a named side output
+)
+
+// ErrorPolicy determines how a stream node handles an error
+// returned while processing an item.
+type ErrorPolicy uint8
+
+const (
+	ErrorPolicySkip       ErrorPolicy = iota // Logs the error and drops the item (default)
+	ErrorPolicyFail                          // Stops the stream and returns the error
+	ErrorPolicyReroute                       // Sends item and error to the ErrorOutput side output
+	ErrorPolicyDeadLetter                    // Sends item and error to the stream dead-letter output
 )
 
+// ErrorOutput is the name of the side output that receives
+// items rerouted by ErrorPolicyReroute as StreamResult values.
+const ErrorOutput = "errors"
+
+// DeadLetter represents an item rejected by a stream node
+// and sent to the stream dead-letter output.
+type DeadLetter struct {
+	Node     string // Name of the node that rejected the item
+	Reason   string // Reason the item was rejected
+	Expected string // Type expected by the node, for mistyped items
+	Actual   string // Actual type of the item, for mistyped items
+	Item     any    // Original item
+	Err      error  // Error that caused the rejection, if any
+}
+
+// UnexpectedType returns a DeadLetter for an item rejected by
+// the named node because it is not of the expected type T.
+func UnexpectedType[T any](node string, item any) DeadLetter {
+	return DeadLetter{
+		Node:     node,
+		Reason:   "unexpected type",
+		Expected: reflect.TypeFor[T]().String(),
+		Actual:   fmt.Sprintf("%T", item),
+		Item:     item,
+	}
+}
+
 // StreamResult can be used in opertor executors
 // to provide hints to the underlying operator
 // how to handle the result of an operation. It
@@ -71,4 +117,5 @@ type StreamResult struct {
 	Value  any
 	Action StreamAction
 	Err    error
+	Route  string // name of side output used with ActionRerouteItem
 }
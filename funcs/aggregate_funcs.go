@@ -364,3 +364,463 @@ func SortWithFuncFunc[SLICE ~[]ITEM, ITEM any](f func(i, j ITEM) int) ExecFuncti
 		return param0
 	}
 }
+
+// MinByIndexFunc generates an ExecFunction that returns, from incoming batched
+// items, the row holding the least value at the specified position. The batch
+// is expected to be of type:
+//
+//	[][]T - where T is a slice of numbers or strings
+//
+// The function returns the row, of type []T, or nil if no row has a value at pos.
+func MinByIndexFunc[IN ~[][]ITEM, ITEM any](index int) ExecFunction[IN, []ITEM] {
+	return func(ctx context.Context, param0 IN) []ITEM {
+		row, _ := selectBy(param0, indexValue[ITEM](index), -1)
+		return row
+	}
+}
+
+// MinByStructFieldFunc generates an ExecFunction that returns, from incoming
+// batched items, the struct holding the least value for the named field. The
+// batch is expected to be of type:
+//
+//	[]struct{F} - where field F is a number or a string
+//
+// The function returns the struct, or its zero value if no struct has the field.
+func MinByStructFieldFunc[IN ~[]STRUCT, STRUCT any](name string) ExecFunction[IN, STRUCT] {
+	return func(ctx context.Context, param0 IN) STRUCT {
+		item, _ := selectBy(param0, structFieldValue[STRUCT](name), -1)
+		return item
+	}
+}
+
+// MinByMapKeyFunc generates an ExecFunction that returns, from incoming batched
+// items, the map holding the least value for the specified key. The batch is
+// expected to be of type:
+//
+//	[]map[K]V - where V is a number or a string
+//
+// The function returns the map, or nil if no map has the key.
+func MinByMapKeyFunc[IN ~[]map[K]V, K comparable, V any](key K) ExecFunction[IN, map[K]V] {
+	return func(ctx context.Context, param0 IN) map[K]V {
+		item, _ := selectBy(param0, mapKeyValue[K, V](key), -1)
+		return item
+	}
+}
+
+// MinFunc generates an ExecFunction that returns the least of the batched
+// items from upstream. The data is expected to be of the following types:
+//
+//	[]integers
+//	[]floats
+//	[][]integers
+//	[][]floats
+//
+// The function returns the least value, or zero if there is no item.
+func MinFunc[IN ~[]ITEM | ~[][]ITEM, ITEM api.NumericConstraint]() ExecFunction[IN, ITEM] {
+	return func(ctx context.Context, param0 IN) ITEM {
+		result, _ := extreme(items[IN, ITEM](param0), -1)
+		return result
+	}
+}
+
+// MaxByIndexFunc generates an ExecFunction that returns, from incoming batched
+// items, the row holding the greatest value at the specified position. The
+// batch is expected to be of type:
+//
+//	[][]T - where T is a slice of numbers or strings
+//
+// The function returns the row, of type []T, or nil if no row has a value at pos.
+func MaxByIndexFunc[IN ~[][]ITEM, ITEM any](index int) ExecFunction[IN, []ITEM] {
+	return func(ctx context.Context, param0 IN) []ITEM {
+		row, _ := selectBy(param0, indexValue[ITEM](index), 1)
+		return row
+	}
+}
+
+// MaxByStructFieldFunc generates an ExecFunction that returns, from incoming
+// batched items, the struct holding the greatest value for the named field.
+// The batch is expected to be of type:
+//
+//	[]struct{F} - where field F is a number or a string
+//
+// The function returns the struct, or its zero value if no struct has the field.
+func MaxByStructFieldFunc[IN ~[]STRUCT, STRUCT any](name string) ExecFunction[IN, STRUCT] {
+	return func(ctx context.Context, param0 IN) STRUCT {
+		item, _ := selectBy(param0, structFieldValue[STRUCT](name), 1)
+		return item
+	}
+}
+
+// MaxByMapKeyFunc generates an ExecFunction that returns, from incoming batched
+// items, the map holding the greatest value for the specified key. The batch is
+// expected to be of type:
+//
+//	[]map[K]V - where V is a number or a string
+//
+// The function returns the map, or nil if no map has the key.
+func MaxByMapKeyFunc[IN ~[]map[K]V, K comparable, V any](key K) ExecFunction[IN, map[K]V] {
+	return func(ctx context.Context, param0 IN) map[K]V {
+		item, _ := selectBy(param0, mapKeyValue[K, V](key), 1)
+		return item
+	}
+}
+
+// MaxFunc generates an ExecFunction that returns the greatest of the batched
+// items from upstream. The data is expected to be of the following types:
+//
+//	[]integers
+//	[]floats
+//	[][]integers
+//	[][]floats
+//
+// The function returns the greatest value, or zero if there is no item.
+func MaxFunc[IN ~[]ITEM | ~[][]ITEM, ITEM api.NumericConstraint]() ExecFunction[IN, ITEM] {
+	return func(ctx context.Context, param0 IN) ITEM {
+		result, _ := extreme(items[IN, ITEM](param0), 1)
+		return result
+	}
+}
+
+// AvgByIndexFunc generates an ExecFunction that averages the numeric values,
+// of incoming batched items, at the specified position. The batch is expected
+// to be of type:
+//
+//	[][]T - where T is a slice of integers or floating points
+//
+// The function returns the average as a float64, or zero if there is no value.
+func AvgByIndexFunc[IN ~[][]ITEM, ITEM any](index int) ExecFunction[IN, float64] {
+	return func(ctx context.Context, param0 IN) float64 {
+		return average(param0, indexValue[ITEM](index))
+	}
+}
+
+// AvgByStructFieldFunc generates an ExecFunction that averages the numeric
+// values of the named field of incoming batched items. The batch is expected
+// to be of type:
+//
+//	[]struct{F} - where field F is an integer or a floating point
+//
+// The function returns the average as a float64, or zero if there is no value.
+func AvgByStructFieldFunc[IN ~[]STRUCT, STRUCT any](name string) ExecFunction[IN, float64] {
+	return func(ctx context.Context, param0 IN) float64 {
+		return average(param0, structFieldValue[STRUCT](name))
+	}
+}
+
+// AvgByMapKeyFunc generates an ExecFunction that averages the numeric values
+// of the specified key of incoming batched items. The batch is expected to be
+// of type:
+//
+//	[]map[K]V - where V is an integer or a floating point
+//
+// The function returns the average as a float64, or zero if there is no value.
+func AvgByMapKeyFunc[IN ~[]map[K]V, K comparable, V any](key K) ExecFunction[IN, float64] {
+	return func(ctx context.Context, param0 IN) float64 {
+		return average(param0, mapKeyValue[K, V](key))
+	}
+}
+
+// AvgFunc generates an ExecFunction that averages batched items from upstream.
+// The data is expected to be of the following types:
+//
+//	[]integers
+//	[]floats
+//	[][]integers
+//	[][]floats
+//
+// The function returns the average as a float64, or zero if there is no item.
+func AvgFunc[IN ~[]ITEM | ~[][]ITEM, ITEM api.NumericConstraint]() ExecFunction[IN, float64] {
+	return func(ctx context.Context, param0 IN) float64 {
+		items := items[IN, ITEM](param0)
+		if len(items) == 0 {
+			return 0
+		}
+		var sum float64
+		for _, item := range items {
+			sum += float64(item)
+		}
+		return sum / float64(len(items))
+	}
+}
+
+// CountByIndexFunc generates an ExecFunction that counts the incoming batched
+// items, of type [][]T, that have a non-nil value at the specified position.
+func CountByIndexFunc[IN ~[][]ITEM, ITEM any](index int) ExecFunction[IN, int] {
+	return func(ctx context.Context, param0 IN) int {
+		return count(param0, indexValue[ITEM](index))
+	}
+}
+
+// CountByStructFieldFunc generates an ExecFunction that counts the incoming
+// batched items, of type []struct{F}, that have a non-nil value for field F.
+func CountByStructFieldFunc[IN ~[]STRUCT, STRUCT any](name string) ExecFunction[IN, int] {
+	return func(ctx context.Context, param0 IN) int {
+		return count(param0, structFieldValue[STRUCT](name))
+	}
+}
+
+// CountByMapKeyFunc generates an ExecFunction that counts the incoming batched
+// items, of type []map[K]V, that have a non-nil value for the specified key.
+func CountByMapKeyFunc[IN ~[]map[K]V, K comparable, V any](key K) ExecFunction[IN, int] {
+	return func(ctx context.Context, param0 IN) int {
+		return count(param0, mapKeyValue[K, V](key))
+	}
+}
+
+// CountFunc generates an ExecFunction that counts batched items from upstream,
+// of type []T or [][]T where the items of every row are counted.
+func CountFunc[IN ~[]ITEM | ~[][]ITEM, ITEM any]() ExecFunction[IN, int] {
+	return func(ctx context.Context, param0 IN) int {
+		return len(items[IN, ITEM](param0))
+	}
+}
+
+// CountDistinctByIndexFunc generates an ExecFunction that counts the distinct
+// values, of incoming batched items of type [][]T, at the specified position.
+func CountDistinctByIndexFunc[IN ~[][]ITEM, ITEM comparable](index int) ExecFunction[IN, int] {
+	return func(ctx context.Context, param0 IN) int {
+		return len(distinctBy(param0, indexValue[ITEM](index)))
+	}
+}
+
+// CountDistinctByStructFieldFunc generates an ExecFunction that counts the
+// distinct values of field F, of incoming batched items of type []struct{F}.
+// Values that are not comparable, such as slices, are not counted.
+func CountDistinctByStructFieldFunc[IN ~[]STRUCT, STRUCT any](name string) ExecFunction[IN, int] {
+	return func(ctx context.Context, param0 IN) int {
+		return len(distinctBy(param0, structFieldValue[STRUCT](name)))
+	}
+}
+
+// CountDistinctByMapKeyFunc generates an ExecFunction that counts the distinct
+// values, of incoming batched items of type []map[K]V, for the specified key.
+func CountDistinctByMapKeyFunc[IN ~[]map[K]V, K, V comparable](key K) ExecFunction[IN, int] {
+	return func(ctx context.Context, param0 IN) int {
+		return len(distinctBy(param0, mapKeyValue[K, V](key)))
+	}
+}
+
+// CountDistinctFunc generates an ExecFunction that counts the distinct
+// batched items from upstream, of type []T or [][]T.
+func CountDistinctFunc[IN ~[]ITEM | ~[][]ITEM, ITEM comparable]() ExecFunction[IN, int] {
+	return func(ctx context.Context, param0 IN) int {
+		seen := make(map[ITEM]struct{})
+		for _, item := range items[IN, ITEM](param0) {
+			seen[item] = struct{}{}
+		}
+		return len(seen)
+	}
+}
+
+// DistinctByIndexFunc generates an ExecFunction that removes, from incoming
+// batched items of type [][]T, the rows whose value at the specified position
+// was seen in a previous row. Rows without a value at pos are removed.
+// The function returns the remaining rows, in order.
+func DistinctByIndexFunc[IN ~[][]ITEM, ITEM comparable](index int) ExecFunction[IN, IN] {
+	return func(ctx context.Context, param0 IN) IN {
+		return distinctBy(param0, indexValue[ITEM](index))
+	}
+}
+
+// DistinctByStructFieldFunc generates an ExecFunction that removes, from
+// incoming batched items of type []struct{F}, the structs whose value for
+// field F was seen in a previous struct. Structs without a comparable value
+// for F are removed. The function returns the remaining structs, in order.
+func DistinctByStructFieldFunc[IN ~[]STRUCT, STRUCT any](name string) ExecFunction[IN, IN] {
+	return func(ctx context.Context, param0 IN) IN {
+		return distinctBy(param0, structFieldValue[STRUCT](name))
+	}
+}
+
+// DistinctByMapKeyFunc generates an ExecFunction that removes, from incoming
+// batched items of type []map[K]V, the maps whose value for the specified key
+// was seen in a previous map. Maps without the key are removed.
+// The function returns the remaining maps, in order.
+func DistinctByMapKeyFunc[IN ~[]map[K]V, K, V comparable](key K) ExecFunction[IN, IN] {
+	return func(ctx context.Context, param0 IN) IN {
+		return distinctBy(param0, mapKeyValue[K, V](key))
+	}
+}
+
+// DistinctFunc generates an ExecFunction that removes duplicate items from
+// batched items of type []T. The function returns the first occurrence of
+// each item, in order.
+func DistinctFunc[IN ~[]ITEM, ITEM comparable]() ExecFunction[IN, IN] {
+	return func(ctx context.Context, param0 IN) IN {
+		seen := make(map[ITEM]struct{})
+		var result IN
+		for _, item := range param0 {
+			if _, ok := seen[item]; !ok {
+				seen[item] = struct{}{}
+				result = append(result, item)
+			}
+		}
+		return result
+	}
+}
+
+// valueFunc returns the value of an item to aggregate, or false if the
+// item has no value, for instance when a struct field does not exist
+type valueFunc[E any] func(E) (reflect.Value, bool)
+
+// indexValue returns a valueFunc for the value of a row at index
+func indexValue[ITEM any](index int) valueFunc[[]ITEM] {
+	return func(row []ITEM) (reflect.Value, bool) {
+		if index < 0 || index >= len(row) {
+			return reflect.Value{}, false
+		}
+		return present(reflect.ValueOf(row[index]))
+	}
+}
+
+// structFieldValue returns a valueFunc for the value of the named field of a struct
+func structFieldValue[STRUCT any](name string) valueFunc[STRUCT] {
+	return func(item STRUCT) (reflect.Value, bool) {
+		val := reflect.ValueOf(item)
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		field := val.FieldByName(name)
+		if !field.IsValid() || !field.CanInterface() {
+			return reflect.Value{}, false
+		}
+		return present(field)
+	}
+}
+
+// mapKeyValue returns a valueFunc for the value of a map key
+func mapKeyValue[K comparable, V any](key K) valueFunc[map[K]V] {
+	return func(item map[K]V) (reflect.Value, bool) {
+		val, ok := item[key]
+		if !ok {
+			return reflect.Value{}, false
+		}
+		return present(reflect.ValueOf(val))
+	}
+}
+
+// present returns val, unwrapped from interfaces, unless it is nil
+func present(val reflect.Value) (reflect.Value, bool) {
+	for val.IsValid() && val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return val, false
+	}
+	switch val.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if val.IsNil() {
+			return val, false
+		}
+	}
+	return val, true
+}
+
+// selectBy returns the item whose value is the least, when want is -1, or the
+// greatest, when want is +1. It returns false if no item has a value.
+func selectBy[S ~[]E, E any](items S, value valueFunc[E], want int) (E, bool) {
+	var selected E
+	var selectedVal reflect.Value
+	found := false
+	for _, item := range items {
+		val, ok := value(item)
+		if !ok {
+			continue
+		}
+		if !found {
+			selected, selectedVal, found = item, val, true
+			continue
+		}
+		if result, ok := reflection.Compare(val, selectedVal); ok && result == want {
+			selected, selectedVal = item, val
+		}
+	}
+	return selected, found
+}
+
+// average returns the average of the numeric values of items
+func average[S ~[]E, E any](items S, value valueFunc[E]) float64 {
+	var sum float64
+	var n int
+	for _, item := range items {
+		val, ok := value(item)
+		if !ok || !(reflection.IsIntValue(val) || reflection.IsFloatValue(val)) {
+			continue
+		}
+		sum += reflection.ValueAsFloat(val)
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// count returns the number of items with a value
+func count[S ~[]E, E any](items S, value valueFunc[E]) int {
+	n := 0
+	for _, item := range items {
+		if _, ok := value(item); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// distinctBy returns the items whose comparable value was not seen in a previous item
+func distinctBy[S ~[]E, E any](items S, value valueFunc[E]) S {
+	seen := make(map[any]struct{})
+	var result S
+	for _, item := range items {
+		val, ok := value(item)
+		if !ok || !val.Comparable() {
+			continue
+		}
+		key := val.Interface()
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// items returns the items of a batch of type []T or [][]T, flattened
+func items[IN ~[]ITEM | ~[][]ITEM, ITEM any](param0 IN) []ITEM {
+	switch v := any(param0).(type) {
+	case []ITEM:
+		return v
+	case [][]ITEM:
+		var result []ITEM
+		for _, row := range v {
+			result = append(result, row...)
+		}
+		return result
+	}
+	// defined types, such as type Batch []T, are not matched above
+	val := reflect.ValueOf(param0)
+	if val.Type().Elem() == reflect.TypeFor[ITEM]() {
+		return val.Convert(reflect.TypeFor[[]ITEM]()).Interface().([]ITEM)
+	}
+	var result []ITEM
+	for i := range val.Len() {
+		result = append(result, val.Index(i).Convert(reflect.TypeFor[[]ITEM]()).Interface().([]ITEM)...)
+	}
+	return result
+}
+
+// extreme returns the least item, when want is -1, or the greatest item,
+// when want is +1. It returns false if there is no item.
+func extreme[ITEM cmp.Ordered](items []ITEM, want int) (ITEM, bool) {
+	if len(items) == 0 {
+		var zero ITEM
+		return zero, false
+	}
+	result := items[0]
+	for _, item := range items[1:] {
+		if cmp.Compare(item, result) == want {
+			result = item
+		}
+	}
+	return result, true
+}
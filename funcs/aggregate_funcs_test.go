@@ -256,3 +256,178 @@ func TestSortWithFunc(t *testing.T) {
 		t.Fatal("Unexpected sort order")
 	}
 }
+
+func TestMinMaxByIndexFunc(t *testing.T) {
+	data := [][]any{
+		{"a", 12, "x"},
+		{"b", uint8(4)},
+		{"c"},
+		{"d", 20.5, nil},
+		{"e", -3},
+	}
+	if row := MinByIndexFunc[[][]any](1)(context.TODO(), data); row[0] != "e" {
+		t.Fatal("unexpected min row:", row)
+	}
+	if row := MaxByIndexFunc[[][]any](1)(context.TODO(), data); row[0] != "d" {
+		t.Fatal("unexpected max row:", row)
+	}
+	if row := MinByIndexFunc[[][]any](5)(context.TODO(), data); row != nil {
+		t.Fatal("expecting nil row, got:", row)
+	}
+	if row := MaxByIndexFunc[[][]string](0)(context.TODO(), [][]string{{"b"}, {"c"}, {"a"}}); row[0] != "c" {
+		t.Fatal("unexpected max row:", row)
+	}
+}
+
+func TestMinMaxByStructFieldFunc(t *testing.T) {
+	type vehicle struct {
+		Name  string
+		Speed int
+	}
+	data := []vehicle{{"Spirit", 400}, {"Voyager", 61000}, {"BigFoot", 60}}
+	if v := MinByStructFieldFunc[[]vehicle]("Speed")(context.TODO(), data); v.Name != "BigFoot" {
+		t.Fatal("unexpected min:", v)
+	}
+	if v := MaxByStructFieldFunc[[]vehicle]("Speed")(context.TODO(), data); v.Name != "Voyager" {
+		t.Fatal("unexpected max:", v)
+	}
+	if v := MaxByStructFieldFunc[[]vehicle]("Name")(context.TODO(), data); v.Name != "Voyager" {
+		t.Fatal("unexpected max:", v)
+	}
+	if v := MinByStructFieldFunc[[]vehicle]("Weight")(context.TODO(), data); v != (vehicle{}) {
+		t.Fatal("expecting zero value, got:", v)
+	}
+}
+
+func TestMinMaxByMapKeyFunc(t *testing.T) {
+	data := []map[string]float64{{"temp": 21.5}, {"temp": -4}, {"hum": 90}, {"temp": 33.1}}
+	if m := MinByMapKeyFunc[[]map[string]float64]("temp")(context.TODO(), data); m["temp"] != -4 {
+		t.Fatal("unexpected min:", m)
+	}
+	if m := MaxByMapKeyFunc[[]map[string]float64]("temp")(context.TODO(), data); m["temp"] != 33.1 {
+		t.Fatal("unexpected max:", m)
+	}
+	if m := MaxByMapKeyFunc[[]map[string]float64]("wind")(context.TODO(), data); m != nil {
+		t.Fatal("expecting nil map, got:", m)
+	}
+}
+
+func TestMinMaxFunc(t *testing.T) {
+	if v := MinFunc[[]int, int]()(context.TODO(), []int{4, -2, 9}); v != -2 {
+		t.Fatal("unexpected min:", v)
+	}
+	if v := MaxFunc[[][]float64, float64]()(context.TODO(), [][]float64{{1.5, 2}, {}, {7.25, 3}}); v != 7.25 {
+		t.Fatal("unexpected max:", v)
+	}
+	if v := MaxFunc[[]uint, uint]()(context.TODO(), nil); v != 0 {
+		t.Fatal("expecting zero, got:", v)
+	}
+}
+
+func TestAvgFuncs(t *testing.T) {
+	rows := [][]any{{"a", 2}, {"b", "n/a"}, {"c", 4.0}, {"d"}, {"e", uint(6)}}
+	if avg := AvgByIndexFunc[[][]any](1)(context.TODO(), rows); avg != 4 {
+		t.Fatal("unexpected average:", avg)
+	}
+
+	type reading struct{ Value float32 }
+	readings := []reading{{1}, {2}, {3}, {4}}
+	if avg := AvgByStructFieldFunc[[]reading]("Value")(context.TODO(), readings); avg != 2.5 {
+		t.Fatal("unexpected average:", avg)
+	}
+
+	maps := []map[string]int{{"x": 10}, {"y": 1}, {"x": 20}}
+	if avg := AvgByMapKeyFunc[[]map[string]int]("x")(context.TODO(), maps); avg != 15 {
+		t.Fatal("unexpected average:", avg)
+	}
+
+	if avg := AvgFunc[[][]int, int]()(context.TODO(), [][]int{{1, 2}, {3}}); avg != 2 {
+		t.Fatal("unexpected average:", avg)
+	}
+	if avg := AvgFunc[[]int, int]()(context.TODO(), nil); avg != 0 {
+		t.Fatal("expecting zero, got:", avg)
+	}
+}
+
+func TestCountFuncs(t *testing.T) {
+	rows := [][]any{{"a", 2}, {"b", nil}, {"c", 4.0}, {"d"}}
+	if n := CountByIndexFunc[[][]any](1)(context.TODO(), rows); n != 2 {
+		t.Fatal("unexpected count:", n)
+	}
+
+	type user struct {
+		Name  string
+		Email *string
+	}
+	email := "a@example.com"
+	users := []user{{"a", &email}, {"b", nil}, {"c", &email}}
+	if n := CountByStructFieldFunc[[]user]("Email")(context.TODO(), users); n != 2 {
+		t.Fatal("unexpected count:", n)
+	}
+
+	maps := []map[string]int{{"x": 10}, {"y": 1}, {"x": 20}}
+	if n := CountByMapKeyFunc[[]map[string]int]("x")(context.TODO(), maps); n != 2 {
+		t.Fatal("unexpected count:", n)
+	}
+
+	if n := CountFunc[[][]string, string]()(context.TODO(), [][]string{{"a", "b"}, {"c"}}); n != 3 {
+		t.Fatal("unexpected count:", n)
+	}
+	type batch []string
+	if n := CountFunc[batch, string]()(context.TODO(), batch{"a", "b"}); n != 2 {
+		t.Fatal("unexpected count:", n)
+	}
+}
+
+func TestCountDistinctFuncs(t *testing.T) {
+	rows := [][]string{{"a", "x"}, {"b", "y"}, {"c", "x"}, {"d"}}
+	if n := CountDistinctByIndexFunc[[][]string](1)(context.TODO(), rows); n != 2 {
+		t.Fatal("unexpected count:", n)
+	}
+
+	type order struct {
+		Customer string
+		Tags     []string
+	}
+	orders := []order{{"a", nil}, {"b", nil}, {"a", nil}}
+	if n := CountDistinctByStructFieldFunc[[]order]("Customer")(context.TODO(), orders); n != 2 {
+		t.Fatal("unexpected count:", n)
+	}
+	if n := CountDistinctByStructFieldFunc[[]order]("Tags")(context.TODO(), orders); n != 0 {
+		t.Fatal("expecting slices to be skipped, got:", n)
+	}
+
+	maps := []map[string]int{{"x": 10}, {"x": 10}, {"x": 20}}
+	if n := CountDistinctByMapKeyFunc[[]map[string]int]("x")(context.TODO(), maps); n != 2 {
+		t.Fatal("unexpected count:", n)
+	}
+
+	if n := CountDistinctFunc[[][]int, int]()(context.TODO(), [][]int{{1, 2}, {2, 3}}); n != 3 {
+		t.Fatal("unexpected count:", n)
+	}
+}
+
+func TestDistinctFuncs(t *testing.T) {
+	rows := [][]string{{"a", "x"}, {"b", "y"}, {"c", "x"}, {"d"}}
+	if result := DistinctByIndexFunc[[][]string](1)(context.TODO(), rows); fmt.Sprint(result) != "[[a x] [b y]]" {
+		t.Fatal("unexpected result:", result)
+	}
+
+	type order struct {
+		Customer string
+		Amount   int
+	}
+	orders := []order{{"a", 1}, {"b", 2}, {"a", 3}}
+	if result := DistinctByStructFieldFunc[[]order]("Customer")(context.TODO(), orders); fmt.Sprint(result) != "[{a 1} {b 2}]" {
+		t.Fatal("unexpected result:", result)
+	}
+
+	maps := []map[string]int{{"x": 10}, {"y": 1}, {"x": 10}, {"x": 20}}
+	if result := DistinctByMapKeyFunc[[]map[string]int]("x")(context.TODO(), maps); fmt.Sprint(result) != "[map[x:10] map[x:20]]" {
+		t.Fatal("unexpected result:", result)
+	}
+
+	if result := DistinctFunc[[]int]()(context.TODO(), []int{3, 1, 3, 2, 1}); fmt.Sprint(result) != "[3 1 2]" {
+		t.Fatal("unexpected result:", result)
+	}
+}
@@ -0,0 +1,43 @@
+package funcs
+
+import (
+	"context"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+// CountAggregator returns an Aggregator that counts items
+func CountAggregator[IN any]() Aggregator[IN, uint64, uint64] {
+	return Aggregator[IN, uint64, uint64]{
+		Add:   func(acc uint64, _ IN) uint64 { return acc + 1 },
+		Merge: func(acc1, acc2 uint64) uint64 { return acc1 + acc2 },
+	}
+}
+
+// SumAggregator returns an Aggregator that sums numeric items.
+// Like SumFunc, the sum is returned as a float64.
+func SumAggregator[IN api.NumericConstraint]() Aggregator[IN, float64, float64] {
+	return Aggregator[IN, float64, float64]{
+		Add:   func(acc float64, item IN) float64 { return acc + float64(item) },
+		Merge: func(acc1, acc2 float64) float64 { return acc1 + acc2 },
+	}
+}
+
+// AggregateFunc generates an ExecFunction that applies an Aggregator to
+// batched items from upstream, such as the windows of a window operator
+func AggregateFunc[IN ~[]ITEM, ITEM, ACC, OUT any](agg Aggregator[ITEM, ACC, OUT]) ExecFunction[IN, OUT] {
+	return func(ctx context.Context, param0 IN) OUT {
+		var acc ACC
+		if agg.Init != nil {
+			acc = agg.Init()
+		}
+		for _, item := range param0 {
+			acc = agg.Add(acc, item)
+		}
+		if agg.Result == nil {
+			result, _ := any(acc).(OUT)
+			return result
+		}
+		return agg.Result(acc)
+	}
+}
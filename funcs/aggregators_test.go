@@ -0,0 +1,21 @@
+package funcs
+
+import "testing"
+
+func TestCountAggregator(t *testing.T) {
+	agg := CountAggregator[string]()
+	acc1 := agg.Add(agg.Add(0, "a"), "b")
+	acc2 := agg.Add(0, "c")
+	if count := agg.Merge(acc1, acc2); count != 3 {
+		t.Fatal("unexpected count:", count)
+	}
+}
+
+func TestSumAggregator(t *testing.T) {
+	agg := SumAggregator[int]()
+	acc1 := agg.Add(agg.Add(0, 1), 2)
+	acc2 := agg.Add(0, -4)
+	if sum := agg.Merge(acc1, acc2); sum != -1 {
+		t.Fatal("unexpected sum:", sum)
+	}
+}
@@ -0,0 +1,405 @@
+package funcs
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+// QuantileSketch estimates the quantiles of a stream of numbers using a
+// bounded amount of memory. It implements a KLL sketch: items are kept in a
+// hierarchy of compactors where, once full, a compactor promotes half of its
+// sorted items to the next level, each promoted item then standing for twice
+// as many items. Sketches can be merged to combine partial results.
+type QuantileSketch struct {
+	k      int
+	levels [][]float64
+	size   int
+	count  uint64
+	odd    bool // alternates the half promoted by compactions
+}
+
+// NewQuantileSketch returns an empty QuantileSketch whose accuracy and memory
+// use grow with k. With k = 200, ranks are estimated within about 1.65%
+// of the number of items. If k is less than 8, 200 is used.
+func NewQuantileSketch(k int) *QuantileSketch {
+	if k < 8 {
+		k = 200
+	}
+	return &QuantileSketch{k: k, levels: [][]float64{nil}}
+}
+
+// Add adds a number to the sketch
+func (s *QuantileSketch) Add(value float64) {
+	s.levels[0] = append(s.levels[0], value)
+	s.size++
+	s.count++
+	s.compress()
+}
+
+// Merge adds the items of other to the sketch, other is left unmodified
+func (s *QuantileSketch) Merge(other *QuantileSketch) {
+	for len(s.levels) < len(other.levels) {
+		s.levels = append(s.levels, nil)
+	}
+	for h, level := range other.levels {
+		s.levels[h] = append(s.levels[h], level...)
+	}
+	s.size += other.size
+	s.count += other.count
+	s.compress()
+}
+
+// Count returns the number of items added to the sketch
+func (s *QuantileSketch) Count() uint64 {
+	return s.count
+}
+
+// Quantile returns the estimated value at quantile q, between 0 and 1. For
+// instance, Quantile(0.95) returns the 95th percentile. It returns zero if
+// the sketch is empty.
+func (s *QuantileSketch) Quantile(q float64) float64 {
+	return s.Quantiles(q)[0]
+}
+
+// Quantiles returns the estimated values at quantiles qs, see Quantile
+func (s *QuantileSketch) Quantiles(qs ...float64) []float64 {
+	type weighted struct {
+		value  float64
+		weight uint64
+	}
+	var items []weighted
+	var total uint64
+	for h, level := range s.levels {
+		for _, value := range level {
+			items = append(items, weighted{value, 1 << h})
+		}
+		total += uint64(len(level)) << h
+	}
+	slices.SortFunc(items, func(a, b weighted) int { return cmp.Compare(a.value, b.value) })
+
+	result := make([]float64, len(qs))
+	if len(items) == 0 {
+		return result
+	}
+	for i, q := range qs {
+		rank := math.Max(0, math.Min(1, q)) * float64(total)
+		var cumulative uint64
+		result[i] = items[len(items)-1].value
+		for _, item := range items {
+			cumulative += item.weight
+			if float64(cumulative) >= rank {
+				result[i] = item.value
+				break
+			}
+		}
+	}
+	return result
+}
+
+// capacity returns the number of items level h holds before it is compacted.
+// Lower levels get smaller capacities, which bounds the size of the sketch.
+func (s *QuantileSketch) capacity(h int) int {
+	depth := len(s.levels) - h - 1
+	return max(2, int(math.Ceil(float64(s.k)*math.Pow(2.0/3.0, float64(depth)))))
+}
+
+// compress compacts levels, from the lowest, until the sketch fits in its capacity
+func (s *QuantileSketch) compress() {
+	for {
+		limit := 0
+		for h := range s.levels {
+			limit += s.capacity(h)
+		}
+		if s.size < limit {
+			return
+		}
+		for h := range s.levels {
+			if len(s.levels[h]) < s.capacity(h) {
+				continue
+			}
+			if h+1 == len(s.levels) {
+				s.levels = append(s.levels, nil)
+			}
+			level := s.levels[h]
+			slices.Sort(level)
+			var keep []float64
+			if len(level)%2 == 1 {
+				keep = append(keep, level[len(level)-1])
+				level = level[:len(level)-1]
+			}
+			start := 0
+			if s.odd {
+				start = 1
+			}
+			s.odd = !s.odd
+			for i := start; i < len(level); i += 2 {
+				s.levels[h+1] = append(s.levels[h+1], level[i])
+			}
+			s.levels[h] = keep
+			s.size -= len(level) / 2
+			break
+		}
+	}
+}
+
+// QuantilesAggregator returns an Aggregator that estimates the quantiles qs,
+// between 0 and 1, of numeric items with a QuantileSketch of accuracy k:
+//
+//	funcs.QuantilesAggregator[float64](200, 0.5, 0.95, 0.99)
+//
+// The result holds the estimated value of each quantile, in the order of qs.
+func QuantilesAggregator[IN api.NumericConstraint](k int, qs ...float64) Aggregator[IN, *QuantileSketch, []float64] {
+	return Aggregator[IN, *QuantileSketch, []float64]{
+		Init: func() *QuantileSketch { return NewQuantileSketch(k) },
+		Add: func(acc *QuantileSketch, item IN) *QuantileSketch {
+			acc.Add(float64(item))
+			return acc
+		},
+		Merge: func(acc1, acc2 *QuantileSketch) *QuantileSketch {
+			acc1.Merge(acc2)
+			return acc1
+		},
+		Result: func(acc *QuantileSketch) []float64 { return acc.Quantiles(qs...) },
+	}
+}
+
+// HyperLogLog estimates the number of distinct items of a stream using a
+// bounded amount of memory: 2^precision bytes. The standard error of the
+// estimate is about 1.04/sqrt(2^precision), or 0.81% for a precision of 14.
+// Sketches of the same precision can be merged to combine partial results.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog returns an empty HyperLogLog. The precision is kept
+// between 4 and 18.
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	precision = min(max(precision, 4), 18)
+	return &HyperLogLog{precision: precision, registers: make([]uint8, 1<<precision)}
+}
+
+// Add adds an item to the sketch. Strings, byte slices and numbers are
+// hashed from their value; other items are hashed from their %#v format.
+func (h *HyperLogLog) Add(item any) {
+	h.AddHash(hashItem(item))
+}
+
+// AddHash adds an item, by its 64-bit hash, to the sketch
+func (h *HyperLogLog) AddHash(hash uint64) {
+	index := hash >> (64 - h.precision)
+	rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1)) + 1)
+	h.registers[index] = max(h.registers[index], rank)
+}
+
+// Merge adds the items of other to the sketch, other is left unmodified.
+// It returns an error, leaving the sketch unchanged, if the sketches do not
+// have the same precision.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return fmt.Errorf("cannot merge HyperLogLog of precision %d into precision %d", other.precision, h.precision)
+	}
+	for i, rank := range other.registers {
+		h.registers[i] = max(h.registers[i], rank)
+	}
+	return nil
+}
+
+// Count returns the estimated number of distinct items added to the sketch
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+	var sum float64
+	var zeros int
+	for _, rank := range h.registers {
+		sum += math.Ldexp(1, -int(rank))
+		if rank == 0 {
+			zeros++
+		}
+	}
+
+	var alpha float64
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	estimate := alpha * m * m / sum
+	// use linear counting for small cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// hashItem returns a 64-bit hash of item, see HyperLogLog.Add
+func hashItem(item any) uint64 {
+	hash := fnv.New64a()
+	switch v := item.(type) {
+	case string:
+		hash.Write([]byte(v))
+	case []byte:
+		hash.Write(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		var data []byte
+		switch v := v.(type) {
+		case int:
+			data = binary.LittleEndian.AppendUint64(nil, uint64(v))
+		case uint:
+			data = binary.LittleEndian.AppendUint64(nil, uint64(v))
+		default:
+			data, _ = binary.Append(nil, binary.LittleEndian, v)
+		}
+		hash.Write(data)
+	default:
+		fmt.Fprintf(hash, "%#v", item)
+	}
+	// mix the bits (splitmix64 finalizer), since the leading bits select registers
+	x := hash.Sum64()
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// CardinalityAggregator returns an Aggregator that estimates the number of
+// distinct items with a HyperLogLog of the given precision, 14 for instance.
+func CardinalityAggregator[IN any](precision uint8) Aggregator[IN, *HyperLogLog, uint64] {
+	return Aggregator[IN, *HyperLogLog, uint64]{
+		Init: func() *HyperLogLog { return NewHyperLogLog(precision) },
+		Add: func(acc *HyperLogLog, item IN) *HyperLogLog {
+			acc.Add(item)
+			return acc
+		},
+		Merge: func(acc1, acc2 *HyperLogLog) *HyperLogLog {
+			// accumulators created by Init share the same precision, a mismatch
+			// is a misuse recovered by operators according to their error policy
+			if err := acc1.Merge(acc2); err != nil {
+				panic(err)
+			}
+			return acc1
+		},
+		Result: func(acc *HyperLogLog) uint64 { return acc.Count() },
+	}
+}
+
+// HeavyHitter is a frequent item reported by a TopKSketch. Its Count may
+// overestimate the occurrences of the item by at most Error.
+type HeavyHitter[T any] struct {
+	Item  T
+	Count uint64
+	Error uint64
+}
+
+// TopKSketch finds the most frequent items of a stream using a bounded
+// number of counters. It implements the Space-Saving algorithm: once all
+// counters are taken, a new item replaces the least frequent item and
+// inherits its count as error. Sketches can be merged to combine partial
+// results.
+type TopKSketch[T comparable] struct {
+	capacity int
+	counters []HeavyHitter[T]
+	index    map[T]int
+}
+
+// NewTopKSketch returns an empty TopKSketch that tracks up to capacity items.
+// The more counters, beyond the number of items reported, the more accurate
+// the counts.
+func NewTopKSketch[T comparable](capacity int) *TopKSketch[T] {
+	capacity = max(capacity, 1)
+	return &TopKSketch[T]{capacity: capacity, index: make(map[T]int, capacity)}
+}
+
+// Add adds an item to the sketch
+func (s *TopKSketch[T]) Add(item T) {
+	if i, ok := s.index[item]; ok {
+		s.counters[i].Count++
+		return
+	}
+	if len(s.counters) < s.capacity {
+		s.index[item] = len(s.counters)
+		s.counters = append(s.counters, HeavyHitter[T]{Item: item, Count: 1})
+		return
+	}
+	least := 0
+	for i, c := range s.counters {
+		if c.Count < s.counters[least].Count {
+			least = i
+		}
+	}
+	floor := s.counters[least].Count
+	delete(s.index, s.counters[least].Item)
+	s.index[item] = least
+	s.counters[least] = HeavyHitter[T]{Item: item, Count: floor + 1, Error: floor}
+}
+
+// Merge adds the items of other to the sketch, other is left unmodified. An
+// item missing from a full sketch may have occurred up to its least count,
+// which is added to the count and error of the item.
+func (s *TopKSketch[T]) Merge(other *TopKSketch[T]) {
+	floor, otherFloor := s.floor(), other.floor()
+	for i := range s.counters {
+		c := &s.counters[i]
+		if j, ok := other.index[c.Item]; ok {
+			c.Count += other.counters[j].Count
+			c.Error += other.counters[j].Error
+			continue
+		}
+		c.Count += otherFloor
+		c.Error += otherFloor
+	}
+	for _, c := range other.counters {
+		if _, ok := s.index[c.Item]; !ok {
+			s.counters = append(s.counters, HeavyHitter[T]{Item: c.Item, Count: c.Count + floor, Error: c.Error + floor})
+		}
+	}
+
+	slices.SortStableFunc(s.counters, func(a, b HeavyHitter[T]) int { return cmp.Compare(b.Count, a.Count) })
+	s.counters = s.counters[:min(len(s.counters), s.capacity)]
+	clear(s.index)
+	for i, c := range s.counters {
+		s.index[c.Item] = i
+	}
+}
+
+// TopK returns the k most frequent items, from the most frequent
+func (s *TopKSketch[T]) TopK(k int) []HeavyHitter[T] {
+	result := slices.Clone(s.counters)
+	slices.SortStableFunc(result, func(a, b HeavyHitter[T]) int { return cmp.Compare(b.Count, a.Count) })
+	return result[:min(len(result), max(k, 0))]
+}
+
+// floor returns the count an item missing from the sketch may have
+func (s *TopKSketch[T]) floor() uint64 {
+	if len(s.counters) < s.capacity {
+		return 0
+	}
+	return slices.MinFunc(s.counters, func(a, b HeavyHitter[T]) int { return cmp.Compare(a.Count, b.Count) }).Count
+}
+
+// TopKAggregator returns an Aggregator that reports the k most frequent
+// items, using a TopKSketch of 10*k counters.
+func TopKAggregator[IN comparable](k int) Aggregator[IN, *TopKSketch[IN], []HeavyHitter[IN]] {
+	return Aggregator[IN, *TopKSketch[IN], []HeavyHitter[IN]]{
+		Init: func() *TopKSketch[IN] { return NewTopKSketch[IN](10 * k) },
+		Add: func(acc *TopKSketch[IN], item IN) *TopKSketch[IN] {
+			acc.Add(item)
+			return acc
+		},
+		Merge: func(acc1, acc2 *TopKSketch[IN]) *TopKSketch[IN] {
+			acc1.Merge(acc2)
+			return acc1
+		},
+		Result: func(acc *TopKSketch[IN]) []HeavyHitter[IN] { return acc.TopK(k) },
+	}
+}
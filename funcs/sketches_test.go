@@ -0,0 +1,124 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestQuantileSketch(t *testing.T) {
+	const n = 100_000
+	values := rand.New(rand.NewPCG(1, 2)).Perm(n)
+
+	agg := QuantilesAggregator[int](200, 0.5, 0.95, 0.99)
+	acc1, acc2 := agg.Init(), agg.Init()
+	for i, v := range values {
+		if i%3 == 0 {
+			acc1 = agg.Add(acc1, v)
+			continue
+		}
+		acc2 = agg.Add(acc2, v)
+	}
+	if len(acc1.levels[0]) > 200 || len(acc2.levels) > 20 {
+		t.Fatal("unexpected sketch size:", len(acc1.levels[0]), len(acc2.levels))
+	}
+
+	acc := agg.Merge(acc1, acc2)
+	if acc.Count() != n {
+		t.Fatal("unexpected count:", acc.Count())
+	}
+	for i, q := range []float64{0.5, 0.95, 0.99} {
+		estimate := agg.Result(acc)[i]
+		if math.Abs(estimate-q*n) > 0.02*n {
+			t.Errorf("quantile %v: estimate %v too far from %v", q, estimate, q*n)
+		}
+	}
+
+	if q := NewQuantileSketch(0).Quantile(0.5); q != 0 {
+		t.Fatal("expecting zero for empty sketch, got:", q)
+	}
+}
+
+func TestHyperLogLog(t *testing.T) {
+	agg := CardinalityAggregator[string](14)
+	acc1, acc2 := agg.Init(), agg.Init()
+	for i := range 60_000 {
+		acc1 = agg.Add(acc1, fmt.Sprintf("user-%d", i))
+	}
+	for i := 40_000; i < 100_000; i++ {
+		acc2 = agg.Add(acc2, fmt.Sprintf("user-%d", i))
+	}
+	count := agg.Result(agg.Merge(acc1, acc2))
+	if math.Abs(float64(count)-100_000) > 0.03*100_000 {
+		t.Fatal("unexpected cardinality:", count)
+	}
+
+	small := NewHyperLogLog(14)
+	for i := range 1000 {
+		small.Add(i % 10)
+	}
+	if small.Count() != 10 {
+		t.Fatal("unexpected cardinality:", small.Count())
+	}
+
+	if err := small.Merge(NewHyperLogLog(10)); err == nil {
+		t.Fatal("expecting error when merging different precisions")
+	}
+	if small.Count() != 10 {
+		t.Fatal("unexpected cardinality after failed merge:", small.Count())
+	}
+}
+
+func TestTopKSketch(t *testing.T) {
+	agg := TopKAggregator[string](3)
+	acc1, acc2 := agg.Init(), agg.Init()
+	r := rand.New(rand.NewPCG(3, 4))
+	// a, b and c are frequent among many rare items
+	for i := range 20_000 {
+		item := fmt.Sprintf("rare-%d", r.IntN(5000))
+		switch i % 10 {
+		case 0, 1, 2, 3:
+			item = "a"
+		case 4, 5:
+			item = "b"
+		case 6:
+			item = "c"
+		}
+		if i%2 == 0 {
+			acc1 = agg.Add(acc1, item)
+			continue
+		}
+		acc2 = agg.Add(acc2, item)
+	}
+
+	top := agg.Result(agg.Merge(acc1, acc2))
+	if len(top) != 3 {
+		t.Fatal("unexpected top-k:", top)
+	}
+	for i, expected := range []struct {
+		item  string
+		count uint64
+	}{{"a", 8000}, {"b", 4000}, {"c", 2000}} {
+		hitter := top[i]
+		if hitter.Item != expected.item {
+			t.Fatal("unexpected top-k:", top)
+		}
+		if hitter.Count < expected.count || hitter.Count-hitter.Error > expected.count {
+			t.Errorf("%s: count %d (error %d) does not bound %d", hitter.Item, hitter.Count, hitter.Error, expected.count)
+		}
+	}
+}
+
+func TestAggregateFunc(t *testing.T) {
+	op := AggregateFunc[[]string](TopKAggregator[string](1))
+	if top := op(context.TODO(), []string{"a", "b", "b", "c"}); fmt.Sprint(top) != "[{b 2 0}]" {
+		t.Fatal("unexpected result:", top)
+	}
+
+	count := AggregateFunc[[]int](CountAggregator[int]())
+	if n := count(context.TODO(), []int{1, 2, 3}); n != 3 {
+		t.Fatal("unexpected count:", n)
+	}
+}
@@ -0,0 +1,251 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/reflection"
+)
+
+// SumTypedByIndexFunc generates an ExecFuncWithErr that sums incoming values,
+// from upstream batched items, at the specified position. Unlike SumByIndexFunc,
+// the sum is of numeric type OUT:
+//
+//	funcs.SumTypedByIndexFunc[int64, [][]any](2)
+//
+// Non-numeric values are skipped. The function returns an error wrapping
+// api.ErrNumericOverflow if the sum overflows OUT, or an error if a value
+// cannot be represented as OUT, for instance 1.5 for an int.
+func SumTypedByIndexFunc[OUT api.NumericConstraint, IN ~[][]ITEM, ITEM any](index int) ExecFuncWithErr[IN, OUT] {
+	return func(ctx context.Context, param0 IN) (OUT, error) {
+		var result OUT
+		for _, row := range param0 {
+			if index < 0 || index >= len(row) {
+				continue
+			}
+			if err := addValue(&result, reflect.ValueOf(row[index])); err != nil {
+				return result, err
+			}
+		}
+		return result, nil
+	}
+}
+
+// SumTypedByStructFieldFunc generates an ExecFuncWithErr that sums the named
+// field of incoming batched structs. Unlike SumByStructFieldFunc, the sum is
+// of numeric type OUT:
+//
+//	funcs.SumTypedByStructFieldFunc[int64, []Order]("Cents")
+//
+// Errors are reported as by SumTypedByIndexFunc.
+func SumTypedByStructFieldFunc[OUT api.NumericConstraint, IN ~[]STRUCT, STRUCT any](name string) ExecFuncWithErr[IN, OUT] {
+	return func(ctx context.Context, param0 IN) (OUT, error) {
+		var result OUT
+		for _, structItem := range param0 {
+			item := reflect.ValueOf(structItem)
+			if item.Kind() != reflect.Struct {
+				continue
+			}
+			field := item.FieldByName(name)
+			if !field.IsValid() || !field.CanInterface() {
+				continue
+			}
+			if err := addValue(&result, field); err != nil {
+				return result, err
+			}
+		}
+		return result, nil
+	}
+}
+
+// SumTypedByMapKeyFunc generates an ExecFuncWithErr that sums the values, of
+// the specified key, of incoming batched maps. Unlike SumByMapKeyFunc, the
+// sum is of numeric type OUT:
+//
+//	funcs.SumTypedByMapKeyFunc[uint64, []map[string]any]("bytes")
+//
+// Errors are reported as by SumTypedByIndexFunc.
+func SumTypedByMapKeyFunc[OUT api.NumericConstraint, IN ~[]map[K]V, K comparable, V any](key K) ExecFuncWithErr[IN, OUT] {
+	return func(ctx context.Context, param0 IN) (OUT, error) {
+		var result OUT
+		for _, mapItem := range param0 {
+			mapVal, ok := mapItem[key]
+			if !ok {
+				continue
+			}
+			if err := addValue(&result, reflect.ValueOf(mapVal)); err != nil {
+				return result, err
+			}
+		}
+		return result, nil
+	}
+}
+
+// SumTypedFunc generates an ExecFuncWithErr that sums batched items from
+// upstream of type []T or [][]T. Unlike SumFunc, the sum is of type T. The
+// function returns an error wrapping api.ErrNumericOverflow if the sum
+// overflows T.
+func SumTypedFunc[IN ~[]ITEM | ~[][]ITEM, ITEM api.NumericConstraint]() ExecFuncWithErr[IN, ITEM] {
+	return func(ctx context.Context, param0 IN) (ITEM, error) {
+		var sum ITEM
+		for _, item := range items[IN, ITEM](param0) {
+			var err error
+			if sum, err = addChecked(sum, item); err != nil {
+				return sum, err
+			}
+		}
+		return sum, nil
+	}
+}
+
+// Number is implemented by arbitrary-precision numeric types, such as decimal
+// types, whose Add method returns the sum of the receiver and its argument.
+type Number[T any] interface {
+	Add(T) T
+}
+
+// BigNumber is implemented by the math/big types *big.Int, *big.Float and
+// *big.Rat, whose Add method sets the receiver to the sum of x and y.
+type BigNumber[T any] interface {
+	*T
+	Add(x, y *T) *T
+}
+
+// SumNumbersFunc generates an ExecFunction that sums batched Number items
+// from upstream. It returns the zero value of T if there is no item.
+func SumNumbersFunc[IN ~[]ITEM, ITEM Number[ITEM]]() ExecFunction[IN, ITEM] {
+	return func(ctx context.Context, param0 IN) ITEM {
+		var sum ITEM
+		for i, item := range param0 {
+			if i == 0 {
+				sum = item
+				continue
+			}
+			sum = sum.Add(item)
+		}
+		return sum
+	}
+}
+
+// SumBigFunc generates an ExecFunction that sums batched math/big numbers
+// from upstream, skipping nil items. The sum is a new value:
+//
+//	funcs.SumBigFunc[[]*big.Int]()
+func SumBigFunc[IN ~[]PT, T any, PT BigNumber[T]]() ExecFunction[IN, PT] {
+	return func(ctx context.Context, param0 IN) PT {
+		sum := PT(new(T))
+		for _, item := range param0 {
+			if item != nil {
+				sum.Add(sum, item)
+			}
+		}
+		return sum
+	}
+}
+
+// addChecked returns a + b, or an error wrapping api.ErrNumericOverflow if
+// the sum overflows T
+func addChecked[T api.NumericConstraint](a, b T) (T, error) {
+	sum := a + b
+	var zero, one T = 0, 1
+	overflow := false
+	if one/2 != zero { // floating point
+		overflow = math.IsInf(float64(sum), 0) && !math.IsInf(float64(a), 0) && !math.IsInf(float64(b), 0)
+	} else {
+		overflow = (b > zero && sum < a) || (b < zero && sum > a)
+	}
+	if overflow {
+		return a, fmt.Errorf("%w: %v + %v overflows %T", api.ErrNumericOverflow, a, b, a)
+	}
+	return sum, nil
+}
+
+// addValue adds val, if numeric, to sum
+func addValue[T api.NumericConstraint](sum *T, val reflect.Value) error {
+	val, ok := present(val)
+	if !ok || !(reflection.IsIntValue(val) || reflection.IsFloatValue(val)) {
+		return nil
+	}
+	converted, ok := convertValue[T](val)
+	if !ok {
+		return fmt.Errorf("value %v of type %s cannot be represented as %s", val, val.Type(), reflect.TypeFor[T]())
+	}
+	result, err := addChecked(*sum, converted)
+	if err != nil {
+		return err
+	}
+	*sum = result
+	return nil
+}
+
+// convertValue converts the numeric val to T. It returns false if val is out
+// of the range of T or, when T is an integer type, if val has a fractional
+// part. Floating point values may lose precision, and NaN is kept as is.
+func convertValue[T api.NumericConstraint](val reflect.Value) (T, bool) {
+	var result T
+	target := reflect.ValueOf(&result).Elem()
+	switch {
+	case target.CanFloat():
+		var f float64
+		switch {
+		case val.CanInt():
+			f = float64(val.Int())
+		case val.CanUint():
+			f = float64(val.Uint())
+		default:
+			f = val.Float()
+		}
+		if target.OverflowFloat(f) {
+			return result, false
+		}
+		target.SetFloat(f)
+
+	case target.CanInt():
+		var i int64
+		switch {
+		case val.CanInt():
+			i = val.Int()
+		case val.CanUint():
+			if val.Uint() > math.MaxInt64 {
+				return result, false
+			}
+			i = int64(val.Uint())
+		default:
+			f := val.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return result, false
+			}
+			i = int64(f)
+		}
+		if target.OverflowInt(i) {
+			return result, false
+		}
+		target.SetInt(i)
+
+	default:
+		var u uint64
+		switch {
+		case val.CanInt():
+			if val.Int() < 0 {
+				return result, false
+			}
+			u = uint64(val.Int())
+		case val.CanUint():
+			u = val.Uint()
+		default:
+			f := val.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				return result, false
+			}
+			u = uint64(f)
+		}
+		if target.OverflowUint(u) {
+			return result, false
+		}
+		target.SetUint(u)
+	}
+	return result, true
+}
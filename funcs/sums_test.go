@@ -0,0 +1,126 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+func TestSumTypedFunc(t *testing.T) {
+	// float64 would round this sum to 9007199254740992
+	sum, err := SumTypedFunc[[]int64, int64]()(context.TODO(), []int64{1 << 53, 1})
+	if err != nil || sum != 1<<53+1 {
+		t.Fatal("unexpected sum:", sum, err)
+	}
+
+	if _, err := SumTypedFunc[[][]int8, int8]()(context.TODO(), [][]int8{{100}, {20, 10}}); !errors.Is(err, api.ErrNumericOverflow) {
+		t.Fatal("expecting overflow error, got:", err)
+	}
+	if _, err := SumTypedFunc[[]uint8, uint8]()(context.TODO(), []uint8{200, 60}); !errors.Is(err, api.ErrNumericOverflow) {
+		t.Fatal("expecting overflow error, got:", err)
+	}
+	if _, err := SumTypedFunc[[]float64, float64]()(context.TODO(), []float64{math.MaxFloat64, math.MaxFloat64}); !errors.Is(err, api.ErrNumericOverflow) {
+		t.Fatal("expecting overflow error, got:", err)
+	}
+	if sum, err := SumTypedFunc[[]int, int]()(context.TODO(), []int{math.MinInt + 1, -1}); err != nil || sum != math.MinInt {
+		t.Fatal("unexpected sum:", sum, err)
+	}
+}
+
+func TestSumTypedByIndexFunc(t *testing.T) {
+	op := SumTypedByIndexFunc[int64, [][]any](1)
+	data := [][]any{{"a", int64(math.MaxInt64 - 10)}, {"b", 4}, {"c"}, {"d", "n/a"}, {"e", uint8(6)}}
+	if sum, err := op(context.TODO(), data); err != nil || sum != math.MaxInt64 {
+		t.Fatal("unexpected sum:", sum, err)
+	}
+
+	data = append(data, []any{"f", 1})
+	if _, err := op(context.TODO(), data); !errors.Is(err, api.ErrNumericOverflow) {
+		t.Fatal("expecting overflow error, got:", err)
+	}
+
+	if _, err := op(context.TODO(), [][]any{{"a", 1.5}}); err == nil || errors.Is(err, api.ErrNumericOverflow) {
+		t.Fatal("expecting representation error, got:", err)
+	}
+	if _, err := SumTypedByIndexFunc[uint, [][]any](0)(context.TODO(), [][]any{{-1}}); err == nil {
+		t.Fatal("expecting representation error for negative unsigned value")
+	}
+	if _, err := op(context.TODO(), [][]any{{"a", uint64(math.MaxUint64)}}); err == nil {
+		t.Fatal("expecting representation error for unsigned value out of int64 range")
+	}
+	if _, err := SumTypedByIndexFunc[int8, [][]any](0)(context.TODO(), [][]any{{1e300}}); err == nil {
+		t.Fatal("expecting representation error for float value out of int8 range")
+	}
+
+	if sum, err := SumTypedByIndexFunc[float64, [][]any](0)(context.TODO(), [][]any{{1.5}, {math.NaN()}}); err != nil || !math.IsNaN(sum) {
+		t.Fatal("unexpected NaN sum:", sum, err)
+	}
+	if sum, err := SumTypedByIndexFunc[float32, [][]any](0)(context.TODO(), [][]any{{0.1}, {0.2}}); err != nil || sum != float32(0.1)+float32(0.2) {
+		t.Fatal("unexpected float32 sum:", sum, err)
+	}
+	if _, err := SumTypedByIndexFunc[float32, [][]any](0)(context.TODO(), [][]any{{math.MaxFloat64}}); err == nil {
+		t.Fatal("expecting representation error for float value out of float32 range")
+	}
+}
+
+func TestSumTypedByStructFieldFunc(t *testing.T) {
+	type payment struct {
+		ID    string
+		Cents int64
+	}
+	data := []payment{{"a", 9_007_199_254_740_993}, {"b", 7}}
+	sum, err := SumTypedByStructFieldFunc[int64, []payment]("Cents")(context.TODO(), data)
+	if err != nil || sum != 9_007_199_254_741_000 {
+		t.Fatal("unexpected sum:", sum, err)
+	}
+	if sum, err := SumTypedByStructFieldFunc[int64, []payment]("Fee")(context.TODO(), data); err != nil || sum != 0 {
+		t.Fatal("unexpected sum:", sum, err)
+	}
+}
+
+func TestSumTypedByMapKeyFunc(t *testing.T) {
+	data := []map[string]any{{"bytes": uint64(math.MaxUint64 - 1)}, {"other": 3}, {"bytes": uint32(1)}}
+	sum, err := SumTypedByMapKeyFunc[uint64, []map[string]any]("bytes")(context.TODO(), data)
+	if err != nil || sum != math.MaxUint64 {
+		t.Fatal("unexpected sum:", sum, err)
+	}
+}
+
+// cents is a minimal decimal-like type implementing Number
+type cents struct{ units, hundredths int64 }
+
+func (c cents) Add(o cents) cents {
+	total := (c.units+o.units)*100 + c.hundredths + o.hundredths
+	return cents{total / 100, total % 100}
+}
+
+func TestSumNumbersFunc(t *testing.T) {
+	sum := SumNumbersFunc[[]cents]()(context.TODO(), []cents{{1, 50}, {2, 75}, {0, 80}})
+	if sum != (cents{5, 5}) {
+		t.Fatal("unexpected sum:", sum)
+	}
+	if sum := SumNumbersFunc[[]cents]()(context.TODO(), nil); sum != (cents{}) {
+		t.Fatal("expecting zero sum, got:", sum)
+	}
+}
+
+func TestSumBigFunc(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	ints := []*big.Int{huge, nil, big.NewInt(10)}
+	sum := SumBigFunc[[]*big.Int]()(context.TODO(), ints)
+	if sum.String() != "123456789012345678901234567900" {
+		t.Fatal("unexpected sum:", sum)
+	}
+	if huge.String() != "123456789012345678901234567890" {
+		t.Fatal("expecting items to be unmodified, got:", huge)
+	}
+
+	rats := []*big.Rat{big.NewRat(1, 3), big.NewRat(1, 6)}
+	if sum := SumBigFunc[[]*big.Rat]()(context.TODO(), rats); sum.RatString() != "1/2" {
+		t.Fatal("unexpected sum:", sum)
+	}
+}
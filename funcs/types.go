@@ -5,4 +5,33 @@ import "context"
 // ExecFunction represents a user-defined function that is executed
 // by an Executor operator
 type ExecFunction[IN any, OUT any] func(context.Context, IN) OUT
+
+// ExecFuncWithErr represents a user-defined function, executed by an Executor
+// operator, that can signal a failure by returning an error
 type ExecFuncWithErr[IN any, OUT any] func(context.Context, IN) (OUT, error)
+
+// Aggregator represents a user-defined incremental aggregation. Rather than
+// batching items, an aggregation folds items, one at a time, into an
+// accumulator and computes its result from the accumulator:
+//
+//	count := Aggregator[Event, int, int]{
+//		Add:   func(acc int, _ Event) int { return acc + 1 },
+//		Merge: func(acc1, acc2 int) int { return acc1 + acc2 },
+//	}
+type Aggregator[IN, ACC, OUT any] struct {
+	// Init returns a new, empty, accumulator. If nil, the zero value of ACC is used.
+	Init func() ACC
+	// Add adds an item to the accumulator and returns the updated accumulator.
+	Add func(acc ACC, item IN) ACC
+	// Merge combines two accumulators. It may modify and return acc1, but not
+	// acc2. Merge is only required by operators that combine partial results,
+	// such as sliding windows.
+	Merge func(acc1, acc2 ACC) ACC
+	// Result returns the result of the aggregation. If nil, the accumulator,
+	// which must then be of type OUT, is returned.
+	Result func(acc ACC) OUT
+}
+
+// FoldFunction represents a user-defined function that folds an item into
+// an accumulator and returns the updated accumulator
+type FoldFunction[IN any, ACC any] func(context.Context, ACC, IN) ACC
@@ -0,0 +1,178 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	autoctx "github.com/vladimirvivien/automi/api/context"
+	"github.com/vladimirvivien/automi/funcs"
+	"github.com/vladimirvivien/automi/log"
+)
+
+// ErrCircuitOpen is returned, for each item, by a function wrapped
+// with CircuitBreaker while the breaker is open and no fallback is set.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// BreakerPolicy configures a function wrapped with CircuitBreaker.
+// Zero values use defaults.
+type BreakerPolicy[IN, OUT any] struct {
+	Threshold int                            // Consecutive failures that open the breaker (default 5)
+	Cooldown  time.Duration                  // Time the breaker stays open before a trial call (default 30s)
+	Fallback  funcs.ExecFuncWithErr[IN, OUT] // Optional function called instead of the wrapped one while open
+	Clock     api.Clock                      // Clock used to time the cool-down period (default api.SystemClock())
+}
+
+// CircuitBreaker returns a function that calls f until f fails Threshold
+// consecutive times. The breaker then opens and, for the Cooldown period, items
+// are passed to the Fallback function or, if none is set, rejected with
+// ErrCircuitOpen. Rejected items are handled by the operator error policy, for
+// instance api.ErrorPolicyReroute sends them to the api.ErrorOutput side output.
+//
+// Once the cool-down period ends, the breaker is half-open: the next item is
+// passed to f as a trial. The breaker closes if the trial succeeds or opens again
+// if it fails. State changes are logged using the stream log function stored
+// in the context.
+//
+//	exec.MapWithErr(exec.CircuitBreaker(exec.BreakerPolicy[string, Record]{Threshold: 3}, lookup), api.ErrorPolicyReroute)
+func CircuitBreaker[IN, OUT any](policy BreakerPolicy[IN, OUT], f funcs.ExecFuncWithErr[IN, OUT]) funcs.ExecFuncWithErr[IN, OUT] {
+	if policy.Threshold < 1 {
+		policy.Threshold = 5
+	}
+	if policy.Cooldown <= 0 {
+		policy.Cooldown = 30 * time.Second
+	}
+	if policy.Clock == nil {
+		policy.Clock = api.SystemClock()
+	}
+	breaker := &circuitBreaker{threshold: policy.Threshold, cooldown: policy.Cooldown, clock: policy.Clock}
+
+	return func(ctx context.Context, in IN) (result OUT, err error) {
+		if !breaker.allow(ctx) {
+			if policy.Fallback != nil {
+				return policy.Fallback(ctx, in)
+			}
+			return result, ErrCircuitOpen
+		}
+
+		defer func() {
+			// record panics as failures before they reach the operator
+			if r := recover(); r != nil {
+				breaker.record(ctx, fmt.Errorf("panic: %v", r))
+				panic(r)
+			}
+		}()
+
+		result, err = f(ctx, in)
+		if api.IsCancelStreamError(err) {
+			// a cancellation is not a failure of the dependency
+			breaker.record(ctx, nil)
+			return result, err
+		}
+		breaker.record(ctx, err)
+		return result, err
+	}
+}
+
+// breakerState represents the state of a circuit breaker
+type breakerState uint8
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker tracks failures of calls made from concurrent operator workers
+type circuitBreaker struct {
+	mutex     sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	clock     api.Clock
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	trial     bool // a half-open trial call is in flight
+}
+
+// allow reports whether a call can proceed
+func (b *circuitBreaker) allow(ctx context.Context) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.setState(ctx, breakerHalfOpen)
+		b.trial = true
+		return true
+	case breakerHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the breaker with the outcome of a call
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if err == nil {
+		switch b.state {
+		case breakerHalfOpen:
+			b.trial = false
+			b.failures = 0
+			b.setState(ctx, breakerClosed)
+		case breakerClosed:
+			b.failures = 0
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || (b.state == breakerClosed && b.failures >= b.threshold) {
+		b.trial = false
+		b.openedAt = b.clock.Now()
+		b.setState(ctx, breakerOpen)
+	}
+}
+
+// setState changes the breaker state and logs the transition
+func (b *circuitBreaker) setState(ctx context.Context, state breakerState) {
+	from := b.state
+	b.state = state
+
+	attrs := []slog.Attr{
+		slog.String("operator", "Exec"),
+		slog.String("from", from.String()),
+		slog.String("to", state.String()),
+		slog.Int("failures", b.failures),
+	}
+	if state == breakerOpen {
+		autoctx.LogF(ctx, log.LogWarn("Circuit breaker state changed", attrs...))
+		return
+	}
+	autoctx.LogF(ctx, log.LogInfo("Circuit breaker state changed", attrs...))
+}
@@ -0,0 +1,189 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	autoctx "github.com/vladimirvivien/automi/api/context"
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errDown := errors.New("dependency down")
+
+	// dependency fails while down is true
+	newDependency := func() (f func(context.Context, int) (int, error), down *bool, calls *int) {
+		down, calls = new(bool), new(int)
+		return func(ctx context.Context, in int) (int, error) {
+			*calls++
+			if *down {
+				return 0, errDown
+			}
+			return in * 10, nil
+		}, down, calls
+	}
+
+	t.Run("opens after threshold", func(t *testing.T) {
+		f, down, calls := newDependency()
+		breaker := CircuitBreaker(BreakerPolicy[int, int]{Threshold: 2, Cooldown: time.Hour}, f)
+		*down = true
+
+		for i := 0; i < 2; i++ {
+			if _, err := breaker(context.Background(), i); !errors.Is(err, errDown) {
+				t.Fatal("expecting dependency error, got:", err)
+			}
+		}
+		if _, err := breaker(context.Background(), 3); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatal("expecting open breaker, got:", err)
+		}
+		if *calls != 2 {
+			t.Fatal("dependency called while breaker open:", *calls)
+		}
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		f, down, _ := newDependency()
+		breaker := CircuitBreaker(BreakerPolicy[int, int]{Threshold: 2, Cooldown: time.Hour}, f)
+
+		*down = true
+		breaker(context.Background(), 1)
+		*down = false
+		breaker(context.Background(), 2)
+		*down = true
+		if _, err := breaker(context.Background(), 3); !errors.Is(err, errDown) {
+			t.Fatal("breaker should still be closed, got:", err)
+		}
+	})
+
+	t.Run("half-open trial", func(t *testing.T) {
+		f, down, calls := newDependency()
+		var mu sync.Mutex
+		var states []string
+		ctx := autoctx.WithLogF(context.Background(), func(ctx context.Context, log api.StreamLog) {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, attr := range log.Attrs {
+				if attr.Key == "to" {
+					states = append(states, attr.Value.String())
+				}
+			}
+		})
+		clock := testutil.NewFakeClock()
+		breaker := CircuitBreaker(BreakerPolicy[int, int]{Threshold: 1, Cooldown: time.Minute, Clock: clock}, f)
+
+		*down = true
+		breaker(ctx, 1) // opens
+		clock.Advance(59 * time.Second)
+		if _, err := breaker(ctx, 2); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatal("expecting open breaker during cool-down, got:", err)
+		}
+		clock.Advance(time.Second)
+		if _, err := breaker(ctx, 2); !errors.Is(err, errDown) { // failed trial opens again
+			t.Fatal("expecting trial call, got:", err)
+		}
+		if _, err := breaker(ctx, 3); !errors.Is(err, ErrCircuitOpen) {
+			t.Fatal("expecting open breaker, got:", err)
+		}
+
+		*down = false
+		clock.Advance(time.Minute)
+		if result, err := breaker(ctx, 4); err != nil || result != 40 { // successful trial closes
+			t.Fatalf("unexpected trial result %d: %v", result, err)
+		}
+		if _, err := breaker(ctx, 5); err != nil {
+			t.Fatal(err)
+		}
+		if *calls != 4 {
+			t.Fatal("unexpected dependency calls:", *calls)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !slices.Equal(states, []string{"open", "half-open", "open", "half-open", "closed"}) {
+			t.Fatal("unexpected state changes:", states)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		f, down, _ := newDependency()
+		breaker := CircuitBreaker(BreakerPolicy[int, int]{
+			Threshold: 1,
+			Cooldown:  time.Hour,
+			Fallback: func(ctx context.Context, in int) (int, error) {
+				return -in, nil
+			},
+		}, f)
+
+		*down = true
+		breaker(context.Background(), 1)
+		if result, err := breaker(context.Background(), 2); err != nil || result != -2 {
+			t.Fatalf("unexpected fallback result %d: %v", result, err)
+		}
+	})
+}
+
+func TestCircuitBreakerOperator(t *testing.T) {
+	op := MapWithErr(CircuitBreaker(BreakerPolicy[int, int]{Threshold: 2, Cooldown: time.Hour},
+		func(ctx context.Context, in int) (int, error) {
+			if in > 2 {
+				return 0, errors.New("dependency down")
+			}
+			return in, nil
+		},
+	), api.ErrorPolicyReroute)
+
+	in := make(chan any)
+	go func() {
+		for i := 1; i <= 6; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+	op.SetInput(in)
+	errs := op.GetSideOutput(api.ErrorOutput)
+	if err := op.Exec(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	var main []int
+	var rejected []any
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for data := range op.GetOutput() {
+			main = append(main, data.(int))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for data := range errs {
+			result := data.(api.StreamResult)
+			if errors.Is(result.Err, ErrCircuitOpen) {
+				rejected = append(rejected, result.Value)
+			}
+		}
+	}()
+	wait := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(wait)
+	}()
+	select {
+	case <-wait:
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("Took too long...")
+	}
+
+	if !slices.Equal(main, []int{1, 2}) {
+		t.Fatal("unexpected output:", main)
+	}
+	if !slices.Equal(rejected, []any{5, 6}) {
+		t.Fatal("unexpected items rejected by open breaker:", rejected)
+	}
+}
@@ -12,6 +12,19 @@ func Execute[IN, OUT any](f funcs.ExecFunction[IN, OUT]) *ExecOperator[IN, OUT]
 	return New(f)
 }
 
+// ExecuteWithErr sets up an executor operator with a user-defined function
+// that can return an error. The specified policy determines how errors are handled:
+//
+//   - api.ErrorPolicySkip: the error is logged and the item is dropped
+//   - api.ErrorPolicyFail: the stream is stopped and the error is returned by Stream.Open
+//   - api.ErrorPolicyReroute: the item and error are sent to the api.ErrorOutput side output
+//   - api.ErrorPolicyDeadLetter: the item and error are sent to the stream dead-letter sink
+func ExecuteWithErr[IN, OUT any](f funcs.ExecFuncWithErr[IN, OUT], policy api.ErrorPolicy) *ExecOperator[IN, OUT] {
+	o := NewWithErr(f)
+	o.SetErrorPolicy(policy)
+	return o
+}
+
 // Filter applies the user-defined ExecFunc to filter out passed data.
 // The provided function must be of type:
 //
@@ -33,3 +46,22 @@ func Filter[IN any](f funcs.ExecFunction[IN, bool]) *ExecOperator[IN, api.Filter
 func Map[IN any, OUT any](f func(context.Context, IN) OUT) *ExecOperator[IN, OUT] {
 	return Execute(f)
 }
+
+// FilterWithErr applies a user-defined function, that can return an error,
+// to filter out passed data. Errors are handled according to the specified policy.
+func FilterWithErr[IN any](f funcs.ExecFuncWithErr[IN, bool], policy api.ErrorPolicy) *ExecOperator[IN, api.FilterItem[IN]] {
+	wrapper := func(ctx context.Context, data IN) (api.FilterItem[IN], error) {
+		predicate, err := f(ctx, data)
+		if err != nil {
+			return api.FilterItem[IN]{}, err
+		}
+		return api.FilterItem[IN]{Predicate: predicate, Item: data}, nil
+	}
+	return ExecuteWithErr(wrapper, policy)
+}
+
+// MapWithErr applies a user-defined function, that can return an error,
+// to map incoming item to new value. Errors are handled according to the specified policy.
+func MapWithErr[IN any, OUT any](f funcs.ExecFuncWithErr[IN, OUT], policy api.ErrorPolicy) *ExecOperator[IN, OUT] {
+	return ExecuteWithErr(f, policy)
+}
@@ -2,7 +2,10 @@ package exec
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -188,3 +191,122 @@ func TestMapFunc(t *testing.T) {
 		runFuncExecutorTest[[]byte, string](t, test)
 	})
 }
+
+func TestExecuteWithErrFunc(t *testing.T) {
+	errOdd := errors.New("odd number")
+	genInput := func() <-chan any {
+		in := make(chan any)
+		go func() {
+			for i := 1; i <= 6; i++ {
+				in <- i
+			}
+			close(in)
+		}()
+		return in
+	}
+	double := func(ctx context.Context, i int) (int, error) {
+		if i%2 != 0 {
+			return 0, errOdd
+		}
+		return i * 2, nil
+	}
+
+	t.Run("skip policy", func(t *testing.T) {
+		test := funcExecutorTest[int, int]{
+			funcExec: ExecuteWithErr(double, api.ErrorPolicySkip),
+			data:     genInput,
+			tester: func(t *testing.T, out <-chan interface{}) {
+				total := 0
+				for data := range out {
+					total += data.(int)
+				}
+				if total != 24 {
+					t.Fatal("unexpected result from operator func:", total)
+				}
+			},
+		}
+		runFuncExecutorTest(t, test)
+	})
+
+	t.Run("fail policy", func(t *testing.T) {
+		var reported atomic.Value
+		o := MapWithErr(double, api.ErrorPolicyFail)
+		o.SetErrFunc(func(ctx context.Context, err error) {
+			reported.Store(err)
+		})
+		test := funcExecutorTest[int, int]{
+			funcExec: o,
+			data:     genInput,
+			tester: func(t *testing.T, out <-chan interface{}) {
+				for range out {
+				}
+			},
+		}
+		runFuncExecutorTest(t, test)
+		if err, _ := reported.Load().(error); !errors.Is(err, errOdd) {
+			t.Fatal("expecting reported error, got:", err)
+		}
+	})
+
+	t.Run("reroute policy", func(t *testing.T) {
+		o := ExecuteWithErr(double, api.ErrorPolicyReroute)
+		errOut := o.GetSideOutput(api.ErrorOutput)
+		var failed []int
+		wait := make(chan struct{})
+		go func() {
+			defer close(wait)
+			for data := range errOut {
+				result := data.(api.StreamResult)
+				if !errors.Is(result.Err, errOdd) {
+					t.Error("unexpected rerouted error:", result.Err)
+				}
+				failed = append(failed, result.Value.(int))
+			}
+		}()
+
+		test := funcExecutorTest[int, int]{
+			funcExec: o,
+			data:     genInput,
+			tester: func(t *testing.T, out <-chan interface{}) {
+				for range out {
+				}
+			},
+		}
+		runFuncExecutorTest(t, test)
+		<-wait
+		if !slices.Equal(failed, []int{1, 3, 5}) {
+			t.Fatal("unexpected rerouted items:", failed)
+		}
+	})
+}
+
+func TestFilterWithErrFunc(t *testing.T) {
+	test := funcExecutorTest[string, api.FilterItem[string]]{
+		funcExec: FilterWithErr(func(ctx context.Context, data string) (bool, error) {
+			if data == "" {
+				return false, errors.New("empty string")
+			}
+			return data != "L", nil
+		}, api.ErrorPolicySkip),
+		data: func() <-chan interface{} {
+			in := make(chan interface{})
+			go func() {
+				for _, s := range []string{"H", "", "E", "L", "L", "O"} {
+					in <- s
+				}
+				close(in)
+			}()
+			return in
+		},
+		tester: func(t *testing.T, out <-chan interface{}) {
+			var result strings.Builder
+			for data := range out {
+				result.WriteString(data.(string))
+			}
+			if result.String() != "HEO" {
+				t.Fatal("unexpected filtered result:", result.String())
+			}
+		},
+	}
+	runFuncExecutorTest(t, test)
+}
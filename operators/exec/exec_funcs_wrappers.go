@@ -62,3 +62,151 @@ func SortByMapKey[MAPS ~[]map[K]V, K comparable, V cmp.Ordered](key K) *ExecOper
 func SortWithFunc[SLICE ~[]ITEM, ITEM any](f func(i, j ITEM) int) *ExecOperator[SLICE, SLICE] {
 	return Execute(funcs.SortWithFuncFunc[SLICE](f))
 }
+
+func MinByIndex[IN ~[][]ITEM, ITEM any](index int) *ExecOperator[IN, []ITEM] {
+	return Execute(funcs.MinByIndexFunc[IN](index))
+}
+
+func MinByStructField[IN ~[]STRUCT, STRUCT any](name string) *ExecOperator[IN, STRUCT] {
+	return Execute(funcs.MinByStructFieldFunc[IN](name))
+}
+
+func MinByMapKey[IN ~[]map[K]V, K comparable, V any](key K) *ExecOperator[IN, map[K]V] {
+	return Execute(funcs.MinByMapKeyFunc[IN](key))
+}
+
+func Min1D[IN ~[]ITEM, ITEM api.NumericConstraint]() *ExecOperator[IN, ITEM] {
+	return Execute(funcs.MinFunc[IN, ITEM]())
+}
+
+func Min2D[IN ~[][]ITEM, ITEM api.NumericConstraint]() *ExecOperator[IN, ITEM] {
+	return Execute(funcs.MinFunc[IN, ITEM]())
+}
+
+func MaxByIndex[IN ~[][]ITEM, ITEM any](index int) *ExecOperator[IN, []ITEM] {
+	return Execute(funcs.MaxByIndexFunc[IN](index))
+}
+
+func MaxByStructField[IN ~[]STRUCT, STRUCT any](name string) *ExecOperator[IN, STRUCT] {
+	return Execute(funcs.MaxByStructFieldFunc[IN](name))
+}
+
+func MaxByMapKey[IN ~[]map[K]V, K comparable, V any](key K) *ExecOperator[IN, map[K]V] {
+	return Execute(funcs.MaxByMapKeyFunc[IN](key))
+}
+
+func Max1D[IN ~[]ITEM, ITEM api.NumericConstraint]() *ExecOperator[IN, ITEM] {
+	return Execute(funcs.MaxFunc[IN, ITEM]())
+}
+
+func Max2D[IN ~[][]ITEM, ITEM api.NumericConstraint]() *ExecOperator[IN, ITEM] {
+	return Execute(funcs.MaxFunc[IN, ITEM]())
+}
+
+func AvgByIndex[IN ~[][]ITEM, ITEM any](index int) *ExecOperator[IN, float64] {
+	return Execute(funcs.AvgByIndexFunc[IN](index))
+}
+
+func AvgByStructField[IN ~[]STRUCT, STRUCT any](name string) *ExecOperator[IN, float64] {
+	return Execute(funcs.AvgByStructFieldFunc[IN](name))
+}
+
+func AvgByMapKey[IN ~[]map[K]V, K comparable, V any](key K) *ExecOperator[IN, float64] {
+	return Execute(funcs.AvgByMapKeyFunc[IN](key))
+}
+
+func Avg1D[IN ~[]ITEM, ITEM api.NumericConstraint]() *ExecOperator[IN, float64] {
+	return Execute(funcs.AvgFunc[IN, ITEM]())
+}
+
+func Avg2D[IN ~[][]ITEM, ITEM api.NumericConstraint]() *ExecOperator[IN, float64] {
+	return Execute(funcs.AvgFunc[IN, ITEM]())
+}
+
+func CountByIndex[IN ~[][]ITEM, ITEM any](index int) *ExecOperator[IN, int] {
+	return Execute(funcs.CountByIndexFunc[IN](index))
+}
+
+func CountByStructField[IN ~[]STRUCT, STRUCT any](name string) *ExecOperator[IN, int] {
+	return Execute(funcs.CountByStructFieldFunc[IN](name))
+}
+
+func CountByMapKey[IN ~[]map[K]V, K comparable, V any](key K) *ExecOperator[IN, int] {
+	return Execute(funcs.CountByMapKeyFunc[IN](key))
+}
+
+func Count1D[IN ~[]ITEM, ITEM any]() *ExecOperator[IN, int] {
+	return Execute(funcs.CountFunc[IN, ITEM]())
+}
+
+func Count2D[IN ~[][]ITEM, ITEM any]() *ExecOperator[IN, int] {
+	return Execute(funcs.CountFunc[IN, ITEM]())
+}
+
+func CountDistinctByIndex[IN ~[][]ITEM, ITEM comparable](index int) *ExecOperator[IN, int] {
+	return Execute(funcs.CountDistinctByIndexFunc[IN](index))
+}
+
+func CountDistinctByStructField[IN ~[]STRUCT, STRUCT any](name string) *ExecOperator[IN, int] {
+	return Execute(funcs.CountDistinctByStructFieldFunc[IN](name))
+}
+
+func CountDistinctByMapKey[IN ~[]map[K]V, K, V comparable](key K) *ExecOperator[IN, int] {
+	return Execute(funcs.CountDistinctByMapKeyFunc[IN](key))
+}
+
+func CountDistinct1D[IN ~[]ITEM, ITEM comparable]() *ExecOperator[IN, int] {
+	return Execute(funcs.CountDistinctFunc[IN, ITEM]())
+}
+
+func CountDistinct2D[IN ~[][]ITEM, ITEM comparable]() *ExecOperator[IN, int] {
+	return Execute(funcs.CountDistinctFunc[IN, ITEM]())
+}
+
+func DistinctByIndex[IN ~[][]ITEM, ITEM comparable](index int) *ExecOperator[IN, IN] {
+	return Execute(funcs.DistinctByIndexFunc[IN](index))
+}
+
+func DistinctByStructField[IN ~[]STRUCT, STRUCT any](name string) *ExecOperator[IN, IN] {
+	return Execute(funcs.DistinctByStructFieldFunc[IN](name))
+}
+
+func DistinctByMapKey[IN ~[]map[K]V, K, V comparable](key K) *ExecOperator[IN, IN] {
+	return Execute(funcs.DistinctByMapKeyFunc[IN](key))
+}
+
+func Distinct[IN ~[]ITEM, ITEM comparable]() *ExecOperator[IN, IN] {
+	return Execute(funcs.DistinctFunc[IN]())
+}
+
+func Aggregate[IN ~[]ITEM, ITEM, ACC, OUT any](agg funcs.Aggregator[ITEM, ACC, OUT]) *ExecOperator[IN, OUT] {
+	return Execute(funcs.AggregateFunc[IN](agg))
+}
+
+func SumTypedByIndex[OUT api.NumericConstraint, IN ~[][]ITEM, ITEM any](index int) *ExecOperator[IN, OUT] {
+	return NewWithErr(funcs.SumTypedByIndexFunc[OUT, IN](index))
+}
+
+func SumTypedByStructField[OUT api.NumericConstraint, IN ~[]STRUCT, STRUCT any](name string) *ExecOperator[IN, OUT] {
+	return NewWithErr(funcs.SumTypedByStructFieldFunc[OUT, IN](name))
+}
+
+func SumTypedByMapKey[OUT api.NumericConstraint, IN ~[]map[K]V, K comparable, V any](key K) *ExecOperator[IN, OUT] {
+	return NewWithErr(funcs.SumTypedByMapKeyFunc[OUT, IN](key))
+}
+
+func SumTyped1D[IN ~[]ITEM, ITEM api.NumericConstraint]() *ExecOperator[IN, ITEM] {
+	return NewWithErr(funcs.SumTypedFunc[IN, ITEM]())
+}
+
+func SumTyped2D[IN ~[][]ITEM, ITEM api.NumericConstraint]() *ExecOperator[IN, ITEM] {
+	return NewWithErr(funcs.SumTypedFunc[IN, ITEM]())
+}
+
+func SumNumbers[IN ~[]ITEM, ITEM funcs.Number[ITEM]]() *ExecOperator[IN, ITEM] {
+	return Execute(funcs.SumNumbersFunc[IN]())
+}
+
+func SumBig[IN ~[]PT, T any, PT funcs.BigNumber[T]]() *ExecOperator[IN, PT] {
+	return Execute(funcs.SumBigFunc[IN]())
+}
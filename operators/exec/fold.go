@@ -0,0 +1,214 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/vladimirvivien/automi/api"
+	autoctx "github.com/vladimirvivien/automi/api/context"
+	"github.com/vladimirvivien/automi/funcs"
+	"github.com/vladimirvivien/automi/log"
+	"github.com/vladimirvivien/automi/operators/internal/node"
+)
+
+// EmitMode specifies when a stateful operator, such as a fold,
+// emits its accumulated value downstream
+type EmitMode uint8
+
+const (
+	EmitFinal   EmitMode = iota // Emits the final value once the input is closed (default)
+	EmitUpdates                 // Emits the updated value after every item
+)
+
+// FoldOperator is an operator node that folds streamed items, one at a time,
+// into an accumulator. See Fold and Reduce.
+type FoldOperator[IN, ACC any] struct {
+	fold    funcs.FoldFunction[IN, ACC]
+	initial ACC
+	seed    func(IN) ACC // sets the accumulator from the first item, used by Reduce
+	mode    EmitMode
+	input   <-chan any
+	output  chan any
+	node    node.Node
+}
+
+// Fold creates an operator that folds streamed items into an accumulator,
+// starting with the initial value, using the fold function. By default, the
+// accumulator is emitted once the input is closed (the initial value if no item
+// was received). Use SetEmitMode with EmitUpdates to emit the accumulator after
+// every item instead, as a running value (a scan):
+//
+//	exec.Fold(0, func(ctx context.Context, total int, o Order) int { return total + o.Qty })
+//
+// Mutable accumulators, such as maps or slices, may be updated in place
+// since the accumulator is only used by the operator goroutine. However,
+// values emitted downstream in EmitUpdates mode would then be shared.
+func Fold[IN, ACC any](initial ACC, f funcs.FoldFunction[IN, ACC]) *FoldOperator[IN, ACC] {
+	return &FoldOperator[IN, ACC]{
+		fold:    f,
+		initial: initial,
+		output:  make(chan any, 1024),
+		node:    node.New("Fold"),
+	}
+}
+
+// Reduce creates an operator that combines streamed items using the reduce
+// function. The first item is the initial value of the reduction, which means
+// nothing is emitted when no item is received. Like Fold, the result is
+// emitted once the input is closed unless SetEmitMode is used:
+//
+//	exec.Reduce(func(ctx context.Context, acc, item int) int { return max(acc, item) })
+func Reduce[IN any](f funcs.FoldFunction[IN, IN]) *FoldOperator[IN, IN] {
+	o := Fold(*new(IN), f)
+	o.seed = func(item IN) IN { return item }
+	return o
+}
+
+// SetEmitMode sets whether the accumulator is emitted once the input is
+// closed, EmitFinal (the default), or after every item, EmitUpdates
+func (o *FoldOperator[IN, ACC]) SetEmitMode(mode EmitMode) {
+	o.mode = mode
+}
+
+// SetErrorPolicy sets how panics recovered from the fold function are
+// handled. The default, api.ErrorPolicySkip, logs the error and drops the
+// item, leaving the accumulator unchanged.
+func (o *FoldOperator[IN, ACC]) SetErrorPolicy(policy api.ErrorPolicy) {
+	o.node.ErrPolicy = policy
+}
+
+// SetInput sets the input channel for the operator node
+func (o *FoldOperator[IN, ACC]) SetInput(in <-chan any) {
+	o.input = in
+}
+
+// GetOutput returns the output channel of the operator node
+func (o *FoldOperator[IN, ACC]) GetOutput() <-chan any {
+	return o.output
+}
+
+// GetSideOutput returns the side output channel with the specified name,
+// creating it if it does not exist. Items are sent to the api.ErrorOutput
+// side output when the error policy is api.ErrorPolicyReroute.
+// Side outputs must be retrieved before Exec is called.
+func (o *FoldOperator[IN, ACC]) GetSideOutput(name string) <-chan any {
+	return o.node.GetSideOutput(name)
+}
+
+// SetLogFunc sets a function called to capture and log stream events
+func (o *FoldOperator[IN, ACC]) SetLogFunc(f api.StreamLogFunc) {
+	o.node.SetLogFunc(f)
+}
+
+// SetErrFunc sets a function called to report runtime errors to the stream
+func (o *FoldOperator[IN, ACC]) SetErrFunc(f api.StreamErrFunc) {
+	o.node.SetErrFunc(f)
+}
+
+// SetDeadLetterFunc sets a function called to send items to the stream
+// dead-letter output when the error policy is api.ErrorPolicyDeadLetter
+func (o *FoldOperator[IN, ACC]) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	o.node.SetDeadLetterFunc(f)
+}
+
+// Exec is the entry point of the operator node
+func (o *FoldOperator[IN, ACC]) Exec(ctx context.Context) error {
+	if o.fold == nil {
+		return fmt.Errorf("fold operator missing function")
+	}
+
+	if o.input == nil {
+		return api.ErrInputChannelUndefined
+	}
+
+	o.node.Logf(ctx, log.LogInfo(
+		"Component starting",
+		slog.String("operator", "Fold"),
+	))
+
+	go func() {
+		exeCtx, cancel := context.WithCancel(autoctx.WithLogF(ctx, o.node.Logf))
+		defer func() {
+			o.node.Logf(ctx, log.LogInfo(
+				"Component closing",
+				slog.String("operator", "Fold"),
+			))
+			cancel()
+			close(o.output)
+			o.node.CloseSideOutputs()
+		}()
+
+		o.run(exeCtx)
+	}()
+	return nil
+}
+
+// run folds items until the input is closed or ctx is done
+func (o *FoldOperator[IN, ACC]) run(ctx context.Context) {
+	acc, seeded := o.initial, o.seed == nil
+	for {
+		select {
+		case item, opened := <-o.input:
+			if !opened {
+				if o.mode == EmitFinal && seeded {
+					o.emit(ctx, acc)
+				}
+				return
+			}
+
+			val, ok := item.(IN)
+			if !ok {
+				o.node.Logf(ctx, log.LogError(
+					"Unexpected type for Func parameter",
+					slog.String("operator", "Fold"),
+					slog.String("type", fmt.Sprintf("%T", item)),
+				))
+				o.node.DeadLetter(ctx, api.UnexpectedType[IN]("Fold", item))
+				continue
+			}
+
+			if seeded {
+				next, err := o.call(ctx, acc, val)
+				if err != nil {
+					if err := o.node.HandleErr(ctx, val, "function error", err); err != nil {
+						o.node.ReportErr(ctx, err)
+						return
+					}
+					continue
+				}
+				acc = next
+			} else {
+				acc, seeded = o.seed(val), true
+			}
+
+			if o.mode == EmitUpdates && !o.emit(ctx, acc) {
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// emit sends acc downstream. It returns false if ctx is done.
+func (o *FoldOperator[IN, ACC]) emit(ctx context.Context, acc ACC) bool {
+	select {
+	case o.output <- acc:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// call applies the fold function to acc and item. A panic raised by
+// the function is recovered and returned as an *api.PanicError.
+func (o *FoldOperator[IN, ACC]) call(ctx context.Context, acc ACC, item IN) (result ACC, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.NewPanicError(r, item)
+		}
+	}()
+	return o.fold(ctx, acc, item), nil
+}
@@ -0,0 +1,109 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+func TestFoldOperator(t *testing.T) {
+	run := func(t *testing.T, o interface {
+		api.Operator
+		SetEmitMode(EmitMode)
+	}, mode EmitMode, items ...any) []any {
+		o.SetEmitMode(mode)
+		var values []any
+		testutil.RunOperatorTest(t, testutil.OperatorTest{
+			Operator: o,
+			Send:     testutil.SendItems(items...),
+			Tester: func(t *testing.T, out <-chan any) {
+				for value := range out {
+					values = append(values, value)
+				}
+			},
+		})
+		return values
+	}
+
+	join := func(_ context.Context, acc string, item int) string {
+		return fmt.Sprintf("%s%d", acc, item)
+	}
+	sum := func(_ context.Context, acc, item int) int { return acc + item }
+
+	tests := []struct {
+		name     string
+		mode     EmitMode
+		items    []any
+		expected string
+	}{
+		{name: "fold final", mode: EmitFinal, items: []any{1, 2, 3}, expected: "[>123]"},
+		{name: "fold updates", mode: EmitUpdates, items: []any{1, 2, 3}, expected: "[>1 >12 >123]"},
+		{name: "fold no item", mode: EmitFinal, expected: "[>]"},
+		{name: "fold unexpected type", mode: EmitFinal, items: []any{1, "2", 3}, expected: "[>13]"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values := run(t, Fold(">", join), test.mode, test.items...)
+			if fmt.Sprint(values) != test.expected {
+				t.Fatal("unexpected values:", values)
+			}
+		})
+	}
+
+	t.Run("reduce final", func(t *testing.T) {
+		if values := run(t, Reduce(sum), EmitFinal, 1, 2, 3, 4); fmt.Sprint(values) != "[10]" {
+			t.Fatal("unexpected values:", values)
+		}
+	})
+
+	t.Run("reduce updates", func(t *testing.T) {
+		if values := run(t, Reduce(sum), EmitUpdates, 1, 2, 3, 4); fmt.Sprint(values) != "[1 3 6 10]" {
+			t.Fatal("unexpected values:", values)
+		}
+	})
+
+	t.Run("reduce no item", func(t *testing.T) {
+		if values := run(t, Reduce(sum), EmitFinal); len(values) != 0 {
+			t.Fatal("unexpected values:", values)
+		}
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		o := Reduce(func(_ context.Context, acc, item int) int {
+			if item == 2 {
+				panic("bad item")
+			}
+			return acc + item
+		})
+		o.SetErrorPolicy(api.ErrorPolicyReroute)
+		errs := o.GetSideOutput(api.ErrorOutput)
+		if values := run(t, o, EmitFinal, 1, 2, 3); fmt.Sprint(values) != "[4]" {
+			t.Fatal("unexpected values:", values)
+		}
+		result := (<-errs).(api.StreamResult)
+		var panicErr *api.PanicError
+		if result.Value != 2 || !errors.As(result.Err, &panicErr) {
+			t.Fatal("unexpected rerouted item:", result)
+		}
+	})
+
+	t.Run("fail policy", func(t *testing.T) {
+		o := Reduce(func(_ context.Context, acc, item int) int {
+			panic("bad item")
+		})
+		o.SetErrorPolicy(api.ErrorPolicyFail)
+		var reported error
+		o.SetErrFunc(func(_ context.Context, err error) { reported = err })
+		if values := run(t, o, EmitFinal, 1, 2, 3); len(values) != 0 {
+			t.Fatal("unexpected values:", values)
+		}
+		var panicErr *api.PanicError
+		if !errors.As(reported, &panicErr) {
+			t.Fatal("unexpected error:", reported)
+		}
+	})
+}
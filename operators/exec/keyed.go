@@ -0,0 +1,322 @@
+package exec
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	autoctx "github.com/vladimirvivien/automi/api/context"
+	"github.com/vladimirvivien/automi/api/tuple"
+	"github.com/vladimirvivien/automi/funcs"
+	"github.com/vladimirvivien/automi/log"
+	"github.com/vladimirvivien/automi/operators/internal/node"
+)
+
+// KeyedAggregateOperator is an operator node that aggregates streamed items
+// by key, keeping the state of each key across items. See KeyedAggregate.
+type KeyedAggregateOperator[IN any, K comparable, ACC, OUT any] struct {
+	key    func(IN) K
+	agg    funcs.Aggregator[IN, ACC, OUT]
+	mode   EmitMode
+	ttl    time.Duration
+	clock  api.Clock
+	states map[K]*keyedState[K, ACC]
+	seq    uint64
+	input  <-chan any
+	output chan any
+	node   node.Node
+}
+
+// keyedState is the aggregation state of a key
+type keyedState[K comparable, ACC any] struct {
+	key     K
+	acc     ACC
+	seq     uint64    // orders keys by creation
+	updated time.Time // time the last item of the key was received
+}
+
+// KeyedAggregate creates an operator that aggregates streamed items by the key
+// returned by the key function, as a streaming GroupBy. Each key gets its own
+// accumulator that items with that key are added to. Results are emitted as
+// tuple.Pair[K, OUT] values holding the key and its aggregation result:
+//
+//	exec.KeyedAggregate(func(o Order) string { return o.Customer }, funcs.CountAggregator[Order]())
+//
+// By default, the result of each key is emitted once the input is closed. Use
+// SetEmitMode with EmitUpdates to emit the updated result of a key after every
+// item instead. Use SetStateTTL to bound the number of keys kept on unbounded
+// streams.
+func KeyedAggregate[IN any, K comparable, ACC, OUT any](key func(IN) K, agg funcs.Aggregator[IN, ACC, OUT]) *KeyedAggregateOperator[IN, K, ACC, OUT] {
+	return &KeyedAggregateOperator[IN, K, ACC, OUT]{
+		key:    key,
+		agg:    agg,
+		clock:  api.SystemClock(),
+		states: make(map[K]*keyedState[K, ACC]),
+		output: make(chan any, 1024),
+		node:   node.New("KeyedAggregate"),
+	}
+}
+
+// SetEmitMode sets whether the result of each key is emitted once the input is
+// closed, EmitFinal (the default), or after every item of the key, EmitUpdates
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) SetEmitMode(mode EmitMode) {
+	o.mode = mode
+}
+
+// SetStateTTL sets how long the state of a key is kept after its last item.
+// Expired keys are dropped, after their result is emitted in EmitFinal mode,
+// and start over from a new accumulator if another item arrives. Keys are
+// checked for expiry four times per TTL, which means a key may be kept up to
+// a quarter of the TTL longer. The default, zero, keeps keys until the input
+// is closed.
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) SetStateTTL(ttl time.Duration) {
+	o.ttl = max(ttl, 0)
+}
+
+// SetClock sets the clock used to timestamp items and to expire
+// key states. It defaults to the system clock.
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) SetClock(clock api.Clock) {
+	if clock == nil {
+		clock = api.SystemClock()
+	}
+	o.clock = clock
+}
+
+// SetErrorPolicy sets how panics recovered from the key or aggregator functions
+// are handled. The default, api.ErrorPolicySkip, logs the error and drops the
+// item, leaving the state of its key unchanged, or the result that failed.
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) SetErrorPolicy(policy api.ErrorPolicy) {
+	o.node.ErrPolicy = policy
+}
+
+// SetInput sets the input channel for the operator node
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) SetInput(in <-chan any) {
+	o.input = in
+}
+
+// GetOutput returns the output channel of the operator node
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) GetOutput() <-chan any {
+	return o.output
+}
+
+// GetSideOutput returns the side output channel with the specified name,
+// creating it if it does not exist. Items are sent to the api.ErrorOutput
+// side output when the error policy is api.ErrorPolicyReroute.
+// Side outputs must be retrieved before Exec is called.
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) GetSideOutput(name string) <-chan any {
+	return o.node.GetSideOutput(name)
+}
+
+// SetLogFunc sets a function called to capture and log stream events
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) SetLogFunc(f api.StreamLogFunc) {
+	o.node.SetLogFunc(f)
+}
+
+// SetErrFunc sets a function called to report runtime errors to the stream
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) SetErrFunc(f api.StreamErrFunc) {
+	o.node.SetErrFunc(f)
+}
+
+// SetDeadLetterFunc sets a function called to send items to the stream
+// dead-letter output when the error policy is api.ErrorPolicyDeadLetter
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	o.node.SetDeadLetterFunc(f)
+}
+
+// Exec is the entry point of the operator node
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) Exec(ctx context.Context) error {
+	if o.key == nil || o.agg.Add == nil {
+		return fmt.Errorf("keyed aggregate operator missing key or aggregator function")
+	}
+
+	if o.input == nil {
+		return api.ErrInputChannelUndefined
+	}
+
+	o.node.Logf(ctx, log.LogInfo(
+		"Component starting",
+		slog.String("operator", "KeyedAggregate"),
+	))
+
+	// expired keys are checked on a ticker
+	var ticker api.Ticker
+	if o.ttl > 0 {
+		ticker = o.clock.NewTicker(max(o.ttl/4, time.Millisecond))
+	}
+
+	go func() {
+		exeCtx, cancel := context.WithCancel(autoctx.WithLogF(ctx, o.node.Logf))
+		defer func() {
+			o.node.Logf(ctx, log.LogInfo(
+				"Component closing",
+				slog.String("operator", "KeyedAggregate"),
+			))
+			cancel()
+			close(o.output)
+			o.node.CloseSideOutputs()
+		}()
+
+		var tick <-chan time.Time
+		if ticker != nil {
+			defer ticker.Stop()
+			tick = ticker.C()
+		}
+		o.run(exeCtx, tick)
+	}()
+	return nil
+}
+
+// run aggregates items until the input is closed or ctx is done,
+// expiring key states on each tick
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) run(ctx context.Context, tick <-chan time.Time) {
+	for {
+		select {
+		case item, opened := <-o.input:
+			if !opened {
+				states := o.sorted(func(*keyedState[K, ACC]) bool { return true })
+				clear(o.states)
+				if o.mode == EmitFinal {
+					o.emit(ctx, states)
+				}
+				return
+			}
+
+			val, ok := item.(IN)
+			if !ok {
+				o.node.Logf(ctx, log.LogError(
+					"Unexpected type for Func parameter",
+					slog.String("operator", "KeyedAggregate"),
+					slog.String("type", fmt.Sprintf("%T", item)),
+				))
+				o.node.DeadLetter(ctx, api.UnexpectedType[IN]("KeyedAggregate", item))
+				continue
+			}
+
+			state, err := o.add(val, o.clock.Now())
+			if err != nil {
+				if err := o.node.HandleErr(ctx, val, "function error", err); err != nil {
+					o.node.ReportErr(ctx, err)
+					return
+				}
+				continue
+			}
+			if o.mode == EmitUpdates && !o.emit(ctx, []*keyedState[K, ACC]{state}) {
+				return
+			}
+
+		case now := <-tick:
+			if !o.expire(ctx, now) {
+				return
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// add adds item to the accumulator of its key, which is only updated, or
+// created for a new key, when the key and aggregator functions succeed
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) add(item IN, now time.Time) (state *keyedState[K, ACC], err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.NewPanicError(r, item)
+		}
+	}()
+
+	key := o.key(item)
+	state, ok := o.states[key]
+	if !ok {
+		var acc ACC
+		if o.agg.Init != nil {
+			acc = o.agg.Init()
+		}
+		state = &keyedState[K, ACC]{key: key, acc: acc, seq: o.seq + 1}
+	}
+	state.acc = o.agg.Add(state.acc, item)
+	state.updated = now
+	if !ok {
+		o.seq++
+		o.states[key] = state
+	}
+	return state, nil
+}
+
+// expire drops the keys that expired at now, emitting their results in
+// EmitFinal mode. It returns false if the operator must stop.
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) expire(ctx context.Context, now time.Time) bool {
+	if o.ttl <= 0 {
+		return true
+	}
+	expired := o.sorted(func(state *keyedState[K, ACC]) bool {
+		return now.Sub(state.updated) >= o.ttl
+	})
+	for _, state := range expired {
+		delete(o.states, state.key)
+	}
+	if len(expired) > 0 {
+		o.node.Logf(ctx, log.LogDebug(
+			"Key state expired",
+			slog.String("operator", "KeyedAggregate"),
+			slog.Int("keys", len(expired)),
+		))
+	}
+	if o.mode != EmitFinal {
+		return true
+	}
+	return o.emit(ctx, expired)
+}
+
+// sorted returns, in key creation order, the key states matching the predicate
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) sorted(predicate func(*keyedState[K, ACC]) bool) []*keyedState[K, ACC] {
+	var states []*keyedState[K, ACC]
+	for _, state := range o.states {
+		if predicate(state) {
+			states = append(states, state)
+		}
+	}
+	slices.SortFunc(states, func(s1, s2 *keyedState[K, ACC]) int {
+		return cmp.Compare(s1.seq, s2.seq)
+	})
+	return states
+}
+
+// emit sends the results of the key states downstream. It returns false
+// if ctx is done or if a result fails and the operator must stop.
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) emit(ctx context.Context, states []*keyedState[K, ACC]) bool {
+	for _, state := range states {
+		result, err := o.result(state)
+		if err != nil {
+			if err := o.node.HandleErr(ctx, state.key, "function error", err); err != nil {
+				o.node.ReportErr(ctx, err)
+				return false
+			}
+			continue
+		}
+		select {
+		case o.output <- tuple.Pair[K, OUT]{Val1: state.key, Val2: result}:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// result returns the aggregation result of a key. A panic raised by
+// the Result function is recovered and returned as an *api.PanicError.
+func (o *KeyedAggregateOperator[IN, K, ACC, OUT]) result(state *keyedState[K, ACC]) (result OUT, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.NewPanicError(r, state.key)
+		}
+	}()
+	if o.agg.Result == nil {
+		result, _ = any(state.acc).(OUT)
+		return result, nil
+	}
+	return o.agg.Result(state.acc), nil
+}
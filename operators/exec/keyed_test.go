@@ -0,0 +1,97 @@
+package exec
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/api/tuple"
+	"github.com/vladimirvivien/automi/funcs"
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+func TestKeyedAggregateOperator(t *testing.T) {
+	type order struct {
+		customer string
+		amount   int
+	}
+	customer := func(o order) string { return o.customer }
+	total := funcs.Aggregator[order, int, string]{
+		Add:    func(acc int, o order) int { return acc + o.amount },
+		Result: func(acc int) string { return fmt.Sprintf("$%d", acc) },
+	}
+
+	run := func(t *testing.T, o *KeyedAggregateOperator[order, string, int, string], send func(chan<- any)) []string {
+		var results []string
+		testutil.RunOperatorTest(t, testutil.OperatorTest{
+			Operator: o,
+			Send:     send,
+			Tester: func(t *testing.T, out <-chan any) {
+				for data := range out {
+					pair := data.(tuple.Pair[string, string])
+					results = append(results, pair.Val1+":"+pair.Val2)
+				}
+			},
+		})
+		return results
+	}
+	orders := []order{{"b", 1}, {"a", 2}, {"b", 3}, {"c", 4}, {"a", 5}}
+
+	tests := []struct {
+		name     string
+		mode     EmitMode
+		expected string
+	}{
+		{name: "final results", mode: EmitFinal, expected: "[b:$4 a:$7 c:$4]"},
+		{name: "updated results", mode: EmitUpdates, expected: "[b:$1 a:$2 b:$4 c:$4 a:$7]"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := KeyedAggregate(customer, total)
+			o.SetEmitMode(test.mode)
+			if results := run(t, o, testutil.SendItems(orders...)); fmt.Sprint(results) != test.expected {
+				t.Fatal("unexpected results:", results)
+			}
+		})
+	}
+
+	t.Run("state expiry", func(t *testing.T) {
+		clock := testutil.NewFakeClock()
+		o := KeyedAggregate(customer, total)
+		o.SetStateTTL(time.Hour)
+		o.SetClock(clock)
+		results := run(t, o, func(in chan<- any) {
+			clock.Send(in, order{"a", 1})
+			clock.Advance(30 * time.Minute)
+			clock.Send(in, order{"b", 2})
+			clock.Send(in, order{"a", 3})
+			clock.Advance(time.Hour) // a and b expire
+			clock.Send(in, order{"b", 4})
+		})
+		if fmt.Sprint(results) != "[a:$4 b:$2 b:$4]" {
+			t.Fatal("unexpected results:", results)
+		}
+		if len(o.states) != 0 {
+			t.Fatal("unexpected key states:", o.states)
+		}
+	})
+
+	t.Run("key panics", func(t *testing.T) {
+		o := KeyedAggregate(func(o order) string {
+			if o.amount < 0 {
+				panic("negative amount")
+			}
+			return o.customer
+		}, total)
+		o.SetErrorPolicy(api.ErrorPolicyReroute)
+		errs := o.GetSideOutput(api.ErrorOutput)
+		results := run(t, o, testutil.SendItems(order{"a", 1}, order{"b", -1}, order{"a", 2}))
+		if fmt.Sprint(results) != "[a:$3]" {
+			t.Fatal("unexpected results:", results)
+		}
+		if rerouted := (<-errs).(api.StreamResult); rerouted.Value != (order{"b", -1}) {
+			t.Fatal("unexpected rerouted item:", rerouted.Value)
+		}
+	})
+}
@@ -4,35 +4,52 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/vladimirvivien/automi/api"
 	autoctx "github.com/vladimirvivien/automi/api/context"
 	"github.com/vladimirvivien/automi/funcs"
 	"github.com/vladimirvivien/automi/log"
+	"github.com/vladimirvivien/automi/operators/internal/node"
 )
 
 // ExecOperator is an operator node that can execute an arbitrary user-defined Go function
 type ExecOperator[IN any, OUT any] struct {
-	opFunc      funcs.ExecFunction[IN, OUT]
+	opFunc      funcs.ExecFuncWithErr[IN, OUT]
 	concurrency int
+	ordered     bool
 	input       <-chan any
 	output      chan any
-	logf        api.StreamLogFunc
+	node        node.Node
 }
 
 // New creates *Operator value
 func New[IN, OUT any](f funcs.ExecFunction[IN, OUT]) *ExecOperator[IN, OUT] {
+	if f == nil {
+		return NewWithErr[IN, OUT](nil)
+	}
+	return NewWithErr(func(ctx context.Context, in IN) (OUT, error) {
+		return f(ctx, in), nil
+	})
+}
+
+// NewWithErr creates *Operator value using a function that can return an error.
+// Returned errors are handled according to the operator's error policy.
+func NewWithErr[IN, OUT any](f funcs.ExecFuncWithErr[IN, OUT]) *ExecOperator[IN, OUT] {
 	// extract logger
 	o := new(ExecOperator[IN, OUT])
 	o.opFunc = f
 	o.concurrency = 1
 	o.output = make(chan any, 1024)
-	o.logf = log.NoLogFunc
+	o.node = node.New("Exec")
 
 	return o
 }
 
-// SetConcurrency sets the concurrency level for the operation
+// SetConcurrency sets the concurrency level for the operation.
+// When greater than one, the operator starts that many workers which
+// read from the input channel together. Use SetOrdered to control
+// whether results keep their input order.
 func (o *ExecOperator[IN, OUT]) SetConcurrency(concurr int) {
 	o.concurrency = concurr
 	if o.concurrency < 1 {
@@ -40,6 +57,21 @@ func (o *ExecOperator[IN, OUT]) SetConcurrency(concurr int) {
 	}
 }
 
+// SetOrdered specifies whether results should be emitted in the same order
+// items were received when the operator runs with a concurrency level greater
+// than one. Ordered output trades throughput for predictability: a slow item
+// holds back results of items that came after it.
+func (o *ExecOperator[IN, OUT]) SetOrdered(ordered bool) {
+	o.ordered = ordered
+}
+
+// SetErrorPolicy sets how errors returned by, or panics recovered from, the
+// operator function are handled. The default, api.ErrorPolicySkip, logs the
+// error and drops the item.
+func (o *ExecOperator[IN, OUT]) SetErrorPolicy(policy api.ErrorPolicy) {
+	o.node.ErrPolicy = policy
+}
+
 // SetInput sets the input channel for the executor node
 func (o *ExecOperator[IN, OUT]) SetInput(in <-chan any) {
 	o.input = in
@@ -50,9 +82,28 @@ func (o *ExecOperator[IN, OUT]) GetOutput() <-chan any {
 	return o.output
 }
 
+// GetSideOutput returns the side output channel with the specified name,
+// creating it if it does not exist. Items are sent to a side output when the
+// operator function returns an api.StreamResult with action api.ActionRerouteItem
+// and a matching route name. Side outputs must be retrieved before Exec is called.
+func (o *ExecOperator[IN, OUT]) GetSideOutput(name string) <-chan any {
+	return o.node.GetSideOutput(name)
+}
+
 // SetLogFunc sets a function called to capture and log stream events
 func (o *ExecOperator[IN, OUT]) SetLogFunc(f api.StreamLogFunc) {
-	o.logf = f
+	o.node.SetLogFunc(f)
+}
+
+// SetErrFunc sets a function called to report runtime errors to the stream
+func (o *ExecOperator[IN, OUT]) SetErrFunc(f api.StreamErrFunc) {
+	o.node.SetErrFunc(f)
+}
+
+// SetDeadLetterFunc sets a function called to send items to the stream
+// dead-letter output when the error policy is api.ErrorPolicyDeadLetter
+func (o *ExecOperator[IN, OUT]) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	o.node.SetDeadLetterFunc(f)
 }
 
 // Exec is the entry point for the executor
@@ -65,18 +116,19 @@ func (o *ExecOperator[IN, OUT]) Exec(ctx context.Context) (err error) {
 		return api.ErrInputChannelUndefined
 	}
 
-	o.logf(ctx, log.LogInfo(
+	o.node.Logf(ctx, log.LogInfo(
 		"Component starting",
 		slog.String("operator", "Exec"),
 	))
 
 	go func() {
 		defer func() {
-			o.logf(ctx, log.LogInfo(
+			o.node.Logf(ctx, log.LogInfo(
 				"Component closing",
 				slog.String("operator", "Exec"),
 			))
 			close(o.output)
+			o.node.CloseSideOutputs()
 		}()
 
 		o.doOp(ctx)
@@ -85,91 +137,257 @@ func (o *ExecOperator[IN, OUT]) Exec(ctx context.Context) (err error) {
 }
 
 func (o *ExecOperator[IN, OUT]) doOp(ctx context.Context) {
-	logCtx := autoctx.WithLogF(ctx, o.logf)
+	logCtx := autoctx.WithLogF(ctx, o.node.Logf)
 	exeCtx, cancel := context.WithCancel(logCtx)
 
 	defer func() {
 		cancel()
 	}()
 
+	switch {
+	case o.concurrency == 1:
+		o.runWorker(exeCtx, cancel)
+	case o.ordered:
+		o.runOrdered(exeCtx, cancel)
+	default:
+		o.runUnordered(exeCtx, cancel)
+	}
+}
+
+// runWorker reads items from the input channel, applies the operator
+// function, and emits the results until the input is closed or the
+// context is canceled. A fatal error cancels the operator.
+func (o *ExecOperator[IN, OUT]) runWorker(ctx context.Context, cancel context.CancelFunc) {
 	for {
 		select {
 		// process incoming item
 		case item, opened := <-o.input:
 			if !opened {
-				o.logf(ctx, log.LogDebug(
+				o.node.Logf(ctx, log.LogDebug(
 					"Component channel closed",
 					slog.String("operator", "Exec"),
 				))
 				return
 			}
 
-			param0, ok := any(item).(IN)
+			result, ok, err := o.process(ctx, item)
+			if err != nil {
+				o.fail(ctx, cancel, err)
+				return
+			}
 			if !ok {
-				o.logf(ctx, log.LogError(
-					"Unexpected type for Func parameter",
-					slog.String("operator", "Exec"),
-					slog.String("type", fmt.Sprintf("%T", item)),
-				))
 				continue
 			}
-			result := o.opFunc(exeCtx, param0)
+			select {
+			case o.output <- result:
+			case <-ctx.Done():
+				return
+			}
 
-			switch val := any(result).(type) {
-			case nil:
-				continue
-			case api.FilterItem[IN]:
-				// apply filter predicate
-				if val.Predicate {
-					select {
-					case o.output <- val.Item:
-					case <-exeCtx.Done():
-						return
-					}
-					continue
-				}
+		// is cancelling
+		case <-ctx.Done():
+			o.node.Logf(ctx, log.LogDebug(
+				"Component context canceled",
+				slog.String("operator", "Exec"),
+			))
+			return
+		}
+	}
+}
+
+// runUnordered starts o.concurrency workers that share the input channel.
+// Results are emitted as soon as they are available which means output
+// order may differ from input order.
+func (o *ExecOperator[IN, OUT]) runUnordered(ctx context.Context, cancel context.CancelFunc) {
+	var wg sync.WaitGroup
+	for i := 0; i < o.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.runWorker(ctx, cancel)
+		}()
+	}
+	wg.Wait()
+}
 
-			case api.StreamResult:
-				item := val.Value
-				err := val.Err
-				action := val.Action
+// execJob is a sequenced unit of work used when output order is preserved
+type execJob struct {
+	seq    uint64
+	item   any
+	result chan execResult
+}
+
+// execResult is the outcome of an execJob
+type execResult struct {
+	value any
+	ok    bool
+	err   error
+}
+
+// runOrdered starts o.concurrency workers that share the input channel
+// while emitting results in the same order that items were received.
+// Each item is tagged with a sequence number and queued in a pending channel
+// bounded by the concurrency level; results are emitted by draining
+// the pending queue in sequence.
+func (o *ExecOperator[IN, OUT]) runOrdered(ctx context.Context, cancel context.CancelFunc) {
+	jobs := make(chan execJob, o.concurrency)
+	pending := make(chan execJob, o.concurrency)
+
+	// dispatch incoming items as sequenced jobs
+	go func() {
+		defer func() {
+			close(jobs)
+			close(pending)
+		}()
 
-				// handle error
-				if err != nil {
-					o.logf(ctx, log.LogDebug(
-						"Error: function execution",
+		var seq uint64
+		for {
+			select {
+			case item, opened := <-o.input:
+				if !opened {
+					o.node.Logf(ctx, log.LogDebug(
+						"Component channel closed",
 						slog.String("operator", "Exec"),
-						slog.String("error", err.Error()),
 					))
+					return
 				}
-				switch action {
-				case api.ActionSkipItem:
-					continue
-				case api.ActionRerouteItem:
-					// Not implemented yet
-				}
+				job := execJob{seq: seq, item: item, result: make(chan execResult, 1)}
+				seq++
 
 				select {
-				case o.output <- item:
-				case <-exeCtx.Done():
+				case pending <- job:
+				case <-ctx.Done():
 					return
 				}
-
-			default:
 				select {
-				case o.output <- result:
-				case <-exeCtx.Done():
+				case jobs <- job:
+				case <-ctx.Done():
 					return
 				}
+			case <-ctx.Done():
+				return
 			}
+		}
+	}()
 
-		// is cancelling
-		case <-exeCtx.Done():
-			o.logf(ctx, log.LogDebug(
-				"Component context canceled",
+	// start workers
+	var wg sync.WaitGroup
+	for i := 0; i < o.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				value, ok, err := o.process(ctx, job.item)
+				job.result <- execResult{value: value, ok: ok, err: err}
+			}
+		}()
+	}
+
+	// emit results in sequence
+	defer wg.Wait()
+	for job := range pending {
+		var result execResult
+		select {
+		case result = <-job.result:
+		case <-ctx.Done():
+			return
+		}
+		if result.err != nil {
+			o.fail(ctx, cancel, result.err)
+			return
+		}
+		if !result.ok {
+			continue
+		}
+		select {
+		case o.output <- result.value:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// process applies the operator function to item and returns the value
+// to emit downstream. It returns false when nothing should be emitted and
+// a non-nil error when the error policy requires the operator to stop.
+func (o *ExecOperator[IN, OUT]) process(ctx context.Context, item any) (any, bool, error) {
+	param0, ok := any(item).(IN)
+	if !ok {
+		o.node.Logf(ctx, log.LogError(
+			"Unexpected type for Func parameter",
+			slog.String("operator", "Exec"),
+			slog.String("type", fmt.Sprintf("%T", item)),
+		))
+		o.node.DeadLetter(ctx, api.UnexpectedType[IN]("Exec", item))
+		return nil, false, nil
+	}
+	result, err := o.call(ctx, param0)
+	if err != nil {
+		return nil, false, o.node.HandleErr(ctx, param0, "function error", err)
+	}
+
+	switch val := any(result).(type) {
+	case nil:
+		return nil, false, nil
+	case api.FilterItem[IN]:
+		// apply filter predicate
+		if val.Predicate {
+			return val.Item, true, nil
+		}
+		return nil, false, nil
+
+	case api.StreamResult:
+		// handle error
+		if val.Err != nil {
+			o.node.Logf(ctx, log.LogDebug(
+				"Error: function execution",
 				slog.String("operator", "Exec"),
+				slog.String("error", val.Err.Error()),
 			))
-			return
+			if api.IsCancelStreamError(val.Err) || api.IsStreamError(val.Err) {
+				o.node.ReportErr(ctx, val.Err)
+			}
+		}
+		switch val.Action {
+		case api.ActionSkipItem:
+			return nil, false, nil
+		case api.ActionRerouteItem:
+			o.node.Reroute(ctx, val.Route, val.Value)
+			return nil, false, nil
+		}
+		return val.Value, true, nil
+
+	case error:
+		// stream errors returned as values are signaled to the stream
+		if api.IsCancelStreamError(val) || api.IsStreamError(val) {
+			o.node.ReportErr(ctx, val)
+			return nil, false, nil
 		}
+		return result, true, nil
+
+	default:
+		return result, true, nil
 	}
 }
+
+// call applies the operator function to item. A panic raised by the
+// function is recovered and returned as an *api.PanicError.
+func (o *ExecOperator[IN, OUT]) call(ctx context.Context, item IN) (result OUT, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.NewPanicError(r, item)
+		}
+	}()
+	return o.opFunc(ctx, item)
+}
+
+// fail reports a fatal error to the stream then stops the operator
+func (o *ExecOperator[IN, OUT]) fail(ctx context.Context, cancel context.CancelFunc, err error) {
+	o.node.Logf(ctx, log.LogError(
+		"Error: function execution: stopping operator",
+		slog.String("operator", "Exec"),
+		slog.String("error", err.Error()),
+	))
+	o.node.ReportErr(ctx, err)
+	cancel()
+}
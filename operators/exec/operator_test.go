@@ -3,8 +3,10 @@ package exec
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -279,6 +281,226 @@ func TestExecOperator(t *testing.T) {
 	})
 }
 
+func TestExecOperatorReroute(t *testing.T) {
+	in := make(chan any)
+	go func() {
+		for _, word := range []string{"HELLO", "hi", "WORLD", "bye"} {
+			in <- word
+		}
+		close(in)
+	}()
+
+	o := New(func(ctx context.Context, data string) api.StreamResult {
+		if strings.ToUpper(data) != data {
+			return api.StreamResult{Value: data, Action: api.ActionRerouteItem, Route: "invalid"}
+		}
+		if data == "WORLD" {
+			// unknown routes are dropped
+			return api.StreamResult{Value: data, Action: api.ActionRerouteItem, Route: "unknown"}
+		}
+		return api.StreamResult{Value: data}
+	})
+	o.SetInput(in)
+	invalid := o.GetSideOutput("invalid")
+
+	if err := o.Exec(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	var main, side []string
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for data := range o.GetOutput() {
+			main = append(main, data.(string))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for data := range invalid {
+			side = append(side, data.(string))
+		}
+	}()
+
+	wait := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(wait)
+	}()
+
+	select {
+	case <-wait:
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("Took too long...")
+	}
+
+	if strings.Join(main, " ") != "HELLO" {
+		t.Fatal("unexpected main output:", main)
+	}
+	if strings.Join(side, " ") != "hi bye" {
+		t.Fatal("unexpected side output:", side)
+	}
+}
+
+func TestExecOperatorStreamErrors(t *testing.T) {
+	in := make(chan any)
+	go func() {
+		for _, word := range []string{"HELLO", "bad", "WORLD", "stop"} {
+			in <- word
+		}
+		close(in)
+	}()
+
+	o := New(func(ctx context.Context, data string) api.StreamResult {
+		switch data {
+		case "bad":
+			return api.StreamResult{
+				Err:    api.ErrorWithItem("bad word", api.StreamItem[string]{Item: data}),
+				Action: api.ActionSkipItem,
+			}
+		case "stop":
+			return api.StreamResult{Err: api.CancellationError("stop word"), Action: api.ActionSkipItem}
+		}
+		return api.StreamResult{Value: data}
+	})
+	o.SetInput(in)
+
+	var mu sync.Mutex
+	var reported []error
+	o.SetErrFunc(func(ctx context.Context, err error) {
+		mu.Lock()
+		reported = append(reported, err)
+		mu.Unlock()
+	})
+
+	if err := o.Exec(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	var result []string
+	for data := range o.GetOutput() {
+		result = append(result, data.(string))
+	}
+
+	if strings.Join(result, " ") != "HELLO WORLD" {
+		t.Fatal("unexpected output:", result)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 2 {
+		t.Fatal("expecting 2 reported errors, got", reported)
+	}
+	if !api.IsStreamError(reported[0]) {
+		t.Fatal("expecting item-level error, got", reported[0])
+	}
+	if !api.IsCancelStreamError(reported[1]) {
+		t.Fatal("expecting cancellation error, got", reported[1])
+	}
+}
+
+func TestExecOperatorConcurrency(t *testing.T) {
+	genInput := func(count int) <-chan any {
+		in := make(chan any)
+		go func() {
+			for i := 0; i < count; i++ {
+				in <- i
+			}
+			close(in)
+		}()
+		return in
+	}
+
+	t.Run("unordered workers", func(t *testing.T) {
+		var active, maxActive atomic.Int32
+		o := New(func(ctx context.Context, i int) int {
+			n := active.Add(1)
+			for {
+				m := maxActive.Load()
+				if n <= m || maxActive.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+			return i * 2
+		})
+		o.SetConcurrency(4)
+		o.SetInput(genInput(40))
+
+		if err := o.Exec(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+
+		total, count := 0, 0
+		timeout := time.After(500 * time.Millisecond)
+	loop:
+		for {
+			select {
+			case data, ok := <-o.GetOutput():
+				if !ok {
+					break loop
+				}
+				total += data.(int)
+				count++
+			case <-timeout:
+				t.Fatal("Took too long...")
+			}
+		}
+
+		if count != 40 {
+			t.Fatal("unexpected item count:", count)
+		}
+		if total != 1560 {
+			t.Fatal("unexpected total:", total)
+		}
+		if maxActive.Load() < 2 {
+			t.Fatal("expecting items to be processed concurrently")
+		}
+	})
+
+	t.Run("ordered workers", func(t *testing.T) {
+		o := New(func(ctx context.Context, i int) any {
+			// delay early items to force out-of-order completion
+			time.Sleep(time.Duration(10-i%10) * 100 * time.Microsecond)
+			if i%5 == 0 {
+				return nil
+			}
+			return i
+		})
+		o.SetConcurrency(4)
+		o.SetOrdered(true)
+		o.SetInput(genInput(50))
+
+		if err := o.Exec(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+
+		var result []int
+		timeout := time.After(500 * time.Millisecond)
+	loop:
+		for {
+			select {
+			case data, ok := <-o.GetOutput():
+				if !ok {
+					break loop
+				}
+				result = append(result, data.(int))
+			case <-timeout:
+				t.Fatal("Took too long...")
+			}
+		}
+
+		if len(result) != 40 {
+			t.Fatal("unexpected item count:", len(result))
+		}
+		if !slices.IsSorted(result) {
+			t.Fatal("expecting ordered output, got", result)
+		}
+	})
+}
+
 func BenchmarkExecOperator(b *testing.B) {
 	N := b.N
 
@@ -332,3 +554,97 @@ func BenchmarkExecOperator(b *testing.B) {
 	}
 	m.RUnlock()
 }
+
+func TestExecOperatorPanics(t *testing.T) {
+	run := func(t *testing.T, o *ExecOperator[int, int]) []int {
+		var result []int
+		testutil.RunOperatorTest(t, testutil.OperatorTest{
+			Operator: o,
+			Send:     testutil.SendItems(1, 2, 3, 4),
+			Tester: func(t *testing.T, out <-chan any) {
+				for data := range out {
+					result = append(result, data.(int))
+				}
+			},
+		})
+		return result
+	}
+
+	panicky := func(ctx context.Context, i int) int {
+		if i%2 == 0 {
+			panic("even number")
+		}
+		return i
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		o := New(panicky)
+		if result := run(t, o); !slices.Equal(result, []int{1, 3}) {
+			t.Fatal("unexpected result:", result)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		o := New(panicky)
+		o.SetErrorPolicy(api.ErrorPolicyFail)
+		var reported error
+		o.SetErrFunc(func(ctx context.Context, err error) { reported = err })
+
+		if result := run(t, o); !slices.Equal(result, []int{1}) {
+			t.Fatal("unexpected result:", result)
+		}
+		var panicErr *api.PanicError
+		if !errors.As(reported, &panicErr) {
+			t.Fatalf("expecting *api.PanicError, got %v", reported)
+		}
+		if panicErr.Value != "even number" || panicErr.Item != 2 || len(panicErr.Stack) == 0 {
+			t.Fatalf("unexpected panic error: %#v", panicErr)
+		}
+	})
+
+	t.Run("reroute", func(t *testing.T) {
+		o := New(panicky)
+		o.SetErrorPolicy(api.ErrorPolicyReroute)
+		errs := o.GetSideOutput(api.ErrorOutput)
+		var rerouted []any
+		wait := make(chan struct{})
+		go func() {
+			defer close(wait)
+			for data := range errs {
+				result := data.(api.StreamResult)
+				var panicErr *api.PanicError
+				if !errors.As(result.Err, &panicErr) {
+					t.Errorf("expecting *api.PanicError, got %v", result.Err)
+				}
+				rerouted = append(rerouted, result.Value)
+			}
+		}()
+
+		if result := run(t, o); !slices.Equal(result, []int{1, 3}) {
+			t.Fatal("unexpected result:", result)
+		}
+		<-wait
+		if !slices.Equal(rerouted, []any{2, 4}) {
+			t.Fatal("unexpected rerouted items:", rerouted)
+		}
+	})
+
+	t.Run("dead letter", func(t *testing.T) {
+		o := New(panicky)
+		o.SetErrorPolicy(api.ErrorPolicyDeadLetter)
+		var letters []api.DeadLetter
+		o.SetDeadLetterFunc(func(ctx context.Context, dl api.DeadLetter) {
+			letters = append(letters, dl)
+		})
+
+		if result := run(t, o); !slices.Equal(result, []int{1, 3}) {
+			t.Fatal("unexpected result:", result)
+		}
+		if len(letters) != 2 || letters[0].Item != 2 || letters[1].Item != 4 {
+			t.Fatalf("unexpected dead letters: %#v", letters)
+		}
+		if letters[0].Node != "Exec" || letters[0].Err == nil {
+			t.Fatalf("unexpected dead letter: %#v", letters[0])
+		}
+	})
+}
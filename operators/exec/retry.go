@@ -0,0 +1,98 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	autoctx "github.com/vladimirvivien/automi/api/context"
+	"github.com/vladimirvivien/automi/funcs"
+	"github.com/vladimirvivien/automi/log"
+)
+
+// RetryPolicy configures how a function wrapped with Retry is
+// re-invoked when it returns an error. Zero values use defaults.
+type RetryPolicy struct {
+	MaxAttempts  int              // Maximum number of attempts, including the first one (default 3)
+	InitialDelay time.Duration    // Delay before the first retry (default 100ms)
+	MaxDelay     time.Duration    // Upper bound for the delay between attempts (default unbounded)
+	Multiplier   float64          // Factor applied to the delay after each retry (default 2)
+	Jitter       float64          // Fraction, in [0,1], of the delay that is randomized (default 0)
+	Retryable    func(error) bool // Reports whether an error should be retried (default all errors)
+}
+
+// Retry returns a function that calls f and, when f returns an error, calls it
+// again with exponential backoff as configured by policy. Retrying stops when
+// an attempt succeeds, the error is not retryable, the attempts are exhausted,
+// or the context is done. An api.CancelStreamError is never retried.
+// Each retry is logged using the stream log function stored in the context.
+//
+// The returned function can be used with the *WithErr operator functions:
+//
+//	exec.MapWithErr(exec.Retry(exec.RetryPolicy{MaxAttempts: 5}, lookup), api.ErrorPolicySkip)
+func Retry[IN, OUT any](policy RetryPolicy, f funcs.ExecFuncWithErr[IN, OUT]) funcs.ExecFuncWithErr[IN, OUT] {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 3
+	}
+	if policy.InitialDelay <= 0 {
+		policy.InitialDelay = 100 * time.Millisecond
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = 2
+	}
+	policy.Jitter = min(max(policy.Jitter, 0), 1)
+
+	return func(ctx context.Context, in IN) (OUT, error) {
+		delay := policy.InitialDelay
+		for attempt := 1; ; attempt++ {
+			result, err := f(ctx, in)
+			if err == nil {
+				return result, nil
+			}
+			if api.IsCancelStreamError(err) || (policy.Retryable != nil && !policy.Retryable(err)) {
+				return result, err
+			}
+			if attempt >= policy.MaxAttempts {
+				return result, fmt.Errorf("retry: %d attempts failed: %w", attempt, err)
+			}
+
+			wait := policy.backoff(delay)
+			autoctx.LogF(ctx, log.LogWarn(
+				"Function attempt failed: retrying",
+				slog.String("operator", "Exec"),
+				slog.Int("attempt", attempt),
+				slog.Duration("delay", wait),
+				slog.String("error", err.Error()),
+			))
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return result, errors.Join(err, ctx.Err())
+			}
+
+			delay = time.Duration(float64(delay) * policy.Multiplier)
+			if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+				delay = policy.MaxDelay
+			}
+		}
+	}
+}
+
+// backoff applies jitter to delay, randomizing it
+// within [delay*(1-Jitter), delay*(1+Jitter)]
+func (p RetryPolicy) backoff(delay time.Duration) time.Duration {
+	if p.Jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
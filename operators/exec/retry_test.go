@@ -0,0 +1,148 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+func TestRetry(t *testing.T) {
+	errFlaky := errors.New("flaky")
+	errFatal := errors.New("fatal")
+
+	// flaky fails the specified number of times before succeeding
+	flaky := func(failures int, err error) (fn func(context.Context, string) (string, error), calls *int) {
+		calls = new(int)
+		return func(ctx context.Context, in string) (string, error) {
+			*calls++
+			if *calls <= failures {
+				return "", err
+			}
+			return in + "!", nil
+		}, calls
+	}
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		f, calls := flaky(2, errFlaky)
+		retry := Retry(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}, f)
+		result, err := retry(context.Background(), "hello")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != "hello!" || *calls != 3 {
+			t.Fatalf("unexpected result %q after %d calls", result, *calls)
+		}
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		f, calls := flaky(5, errFlaky)
+		retry := Retry(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, Jitter: 0.5}, f)
+		_, err := retry(context.Background(), "hello")
+		if !errors.Is(err, errFlaky) {
+			t.Fatal("expecting flaky error, got:", err)
+		}
+		if *calls != 3 {
+			t.Fatal("unexpected call count:", *calls)
+		}
+	})
+
+	t.Run("non-retryable error", func(t *testing.T) {
+		f, calls := flaky(5, errFatal)
+		retry := Retry(RetryPolicy{
+			MaxAttempts:  3,
+			InitialDelay: time.Millisecond,
+			Retryable:    func(err error) bool { return !errors.Is(err, errFatal) },
+		}, f)
+		if _, err := retry(context.Background(), "hello"); !errors.Is(err, errFatal) {
+			t.Fatal("expecting fatal error, got:", err)
+		}
+		if *calls != 1 {
+			t.Fatal("unexpected call count:", *calls)
+		}
+	})
+
+	t.Run("cancel stream error", func(t *testing.T) {
+		f, calls := flaky(5, api.CancellationError("stop"))
+		retry := Retry(RetryPolicy{InitialDelay: time.Millisecond}, f)
+		if _, err := retry(context.Background(), "hello"); !api.IsCancelStreamError(err) {
+			t.Fatal("expecting cancel stream error, got:", err)
+		}
+		if *calls != 1 {
+			t.Fatal("unexpected call count:", *calls)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		f, calls := flaky(5, errFlaky)
+		retry := Retry(RetryPolicy{MaxAttempts: 5, InitialDelay: time.Second}, f)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := retry(ctx, "hello")
+		if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errFlaky) {
+			t.Fatal("expecting deadline and flaky errors, got:", err)
+		}
+		if time.Since(start) > 500*time.Millisecond || *calls != 1 {
+			t.Fatalf("retry did not stop on cancellation: %d calls", *calls)
+		}
+	})
+
+	t.Run("backoff", func(t *testing.T) {
+		p := RetryPolicy{MaxDelay: 150 * time.Millisecond, Jitter: 0.2}
+		for range 100 {
+			d := p.backoff(100 * time.Millisecond)
+			if d < 80*time.Millisecond || d > 120*time.Millisecond {
+				t.Fatal("delay out of jitter range:", d)
+			}
+		}
+		if d := p.backoff(time.Second); d != 150*time.Millisecond {
+			t.Fatal("delay not bounded:", d)
+		}
+	})
+}
+
+func TestRetryOperator(t *testing.T) {
+	calls := map[int]int{}
+	op := MapWithErr(Retry(RetryPolicy{MaxAttempts: 2, InitialDelay: time.Millisecond},
+		func(ctx context.Context, in int) (int, error) {
+			calls[in]++
+			if in%2 == 0 && calls[in] == 1 {
+				return 0, errors.New("first attempt fails")
+			}
+			return in * 10, nil
+		},
+	), api.ErrorPolicyFail)
+
+	in := make(chan any)
+	go func() {
+		for i := 1; i <= 4; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+	op.SetInput(in)
+	if err := op.Exec(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	var sum int
+	wait := make(chan struct{})
+	go func() {
+		defer close(wait)
+		for data := range op.GetOutput() {
+			sum += data.(int)
+		}
+	}()
+	select {
+	case <-wait:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Took too long...")
+	}
+	if sum != 100 {
+		t.Fatal("unexpected sum:", sum)
+	}
+}
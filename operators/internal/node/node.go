@@ -0,0 +1,153 @@
+// Package node provides the error policy and side output handling shared by
+// the operator nodes of the operators packages.
+package node
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/log"
+)
+
+// Node is held by operator nodes to handle errors according to their
+// error policy and to send items to their side outputs
+type Node struct {
+	name        string
+	ErrPolicy   api.ErrorPolicy
+	Logf        api.StreamLogFunc
+	sideOutputs map[string]chan any
+	errf        api.StreamErrFunc
+	deadLetterf api.DeadLetterFunc
+}
+
+// New returns a Node for the operator with the specified name,
+// which is used to log events and to label dead letters
+func New(name string) Node {
+	return Node{
+		name:        name,
+		Logf:        log.NoLogFunc,
+		sideOutputs: make(map[string]chan any),
+	}
+}
+
+// GetSideOutput returns the side output channel with the specified name,
+// creating it if it does not exist. Items are sent to the api.ErrorOutput
+// side output when the error policy is api.ErrorPolicyReroute.
+// Side outputs must be retrieved before Exec is called.
+func (n *Node) GetSideOutput(name string) <-chan any {
+	side, ok := n.sideOutputs[name]
+	if !ok {
+		side = make(chan any, 1024)
+		n.sideOutputs[name] = side
+	}
+	return side
+}
+
+// SetLogFunc sets a function called to capture and log stream events
+func (n *Node) SetLogFunc(f api.StreamLogFunc) {
+	n.Logf = f
+}
+
+// SetErrFunc sets a function called to report runtime errors to the stream
+func (n *Node) SetErrFunc(f api.StreamErrFunc) {
+	n.errf = f
+}
+
+// SetDeadLetterFunc sets a function called to send items to the stream
+// dead-letter output when the error policy is api.ErrorPolicyDeadLetter
+func (n *Node) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	n.deadLetterf = f
+}
+
+// HandleErr applies the error policy to err, returned by or recovered from an
+// operator function while processing item. It returns err if the operator must
+// stop. An api.CancelStreamError is always reported to the stream, regardless
+// of the policy, to gracefully end the stream.
+func (n *Node) HandleErr(ctx context.Context, item any, reason string, err error) error {
+	if api.IsCancelStreamError(err) {
+		n.ReportErr(ctx, err)
+		return nil
+	}
+
+	var panicErr *api.PanicError
+	if errors.As(err, &panicErr) {
+		n.Logf(ctx, log.LogError(
+			"Error: function panicked",
+			slog.String("operator", n.name),
+			slog.String("error", err.Error()),
+			slog.String("stack", string(panicErr.Stack)),
+		))
+	}
+
+	switch n.ErrPolicy {
+	case api.ErrorPolicyFail:
+		return err
+	case api.ErrorPolicyReroute:
+		n.Reroute(ctx, api.ErrorOutput, api.StreamResult{
+			Value:  item,
+			Err:    err,
+			Action: api.ActionRerouteItem,
+			Route:  api.ErrorOutput,
+		})
+	case api.ErrorPolicyDeadLetter:
+		n.DeadLetter(ctx, api.DeadLetter{Node: n.name, Reason: reason, Item: item, Err: err})
+	default:
+		n.Logf(ctx, log.LogDebug(
+			"Error: item skipped",
+			slog.String("operator", n.name),
+			slog.String("reason", reason),
+			slog.String("error", err.Error()),
+		))
+		// item-level errors are collected by the stream
+		if api.IsStreamError(err) {
+			n.ReportErr(ctx, err)
+		}
+	}
+	return nil
+}
+
+// ReportErr reports err to the stream, if a stream error func is set
+func (n *Node) ReportErr(ctx context.Context, err error) {
+	if n.errf != nil {
+		n.errf(ctx, err)
+	}
+}
+
+// DeadLetter sends dl to the stream dead-letter output, if a dead-letter func is set
+func (n *Node) DeadLetter(ctx context.Context, dl api.DeadLetter) {
+	if n.deadLetterf == nil {
+		n.Logf(ctx, log.LogWarn(
+			"Dead-letter output not set: item dropped",
+			slog.String("operator", n.name),
+		))
+		return
+	}
+	n.deadLetterf(ctx, dl)
+}
+
+// Reroute sends item to the named side output. Items rerouted to
+// a side output that was never requested are dropped.
+func (n *Node) Reroute(ctx context.Context, name string, item any) {
+	side, ok := n.sideOutputs[name]
+	if !ok {
+		n.Logf(ctx, log.LogWarn(
+			"Side output not found: item dropped",
+			slog.String("operator", n.name),
+			slog.String("route", name),
+		))
+		return
+	}
+	select {
+	case side <- item:
+	case <-ctx.Done():
+	}
+}
+
+// CloseSideOutputs closes the side output channels once the operator is done
+func (n *Node) CloseSideOutputs() {
+	for _, side := range n.sideOutputs {
+		close(side)
+	}
+}
@@ -0,0 +1,57 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+func TestNodeHandleErr(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     api.ErrorPolicy
+		err        error
+		fails      bool
+		reported   int
+		rerouted   int
+		deadLetter int
+	}{
+		{name: "skip", policy: api.ErrorPolicySkip, err: errors.New("boom")},
+		{name: "skip stream error", policy: api.ErrorPolicySkip, err: api.Error("boom"), reported: 1},
+		{name: "fail", policy: api.ErrorPolicyFail, err: errors.New("boom"), fails: true},
+		{name: "reroute", policy: api.ErrorPolicyReroute, err: errors.New("boom"), rerouted: 1},
+		{name: "dead letter", policy: api.ErrorPolicyDeadLetter, err: errors.New("boom"), deadLetter: 1},
+		{name: "cancel stream", policy: api.ErrorPolicyFail, err: api.CancellationError("stop"), reported: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := New("Test")
+			n.ErrPolicy = test.policy
+			var reported int
+			n.SetErrFunc(func(context.Context, error) { reported++ })
+			var dls []api.DeadLetter
+			n.SetDeadLetterFunc(func(_ context.Context, dl api.DeadLetter) { dls = append(dls, dl) })
+			side := n.GetSideOutput(api.ErrorOutput)
+
+			err := n.HandleErr(context.TODO(), "a", "function error", test.err)
+			if (err != nil) != test.fails {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reported != test.reported {
+				t.Errorf("expected %d reported errors, got %d", test.reported, reported)
+			}
+			if len(side) != test.rerouted {
+				t.Errorf("expected %d rerouted items, got %d", test.rerouted, len(side))
+			}
+			if len(dls) != test.deadLetter {
+				t.Fatalf("expected %d dead letters, got %d", test.deadLetter, len(dls))
+			}
+			if len(dls) > 0 && (dls[0].Node != "Test" || dls[0].Reason != "function error" || dls[0].Item != "a") {
+				t.Errorf("unexpected dead letter: %+v", dls[0])
+			}
+		})
+	}
+}
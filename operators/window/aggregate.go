@@ -0,0 +1,289 @@
+package window
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/funcs"
+)
+
+// errNoMerge is returned when the result of a sliding window is computed
+// with an aggregator that has no Merge function
+var errNoMerge = errors.New("window: aggregator Merge function required by sliding windows")
+
+// errKeyedAggregate is returned by Exec when Keyed and Aggregate are
+// combined, which would lose either the keys or the aggregation results
+var errKeyedAggregate = errors.New("window: use KeyedAggregate to aggregate keyed windows")
+
+// aggregation is a funcs.Aggregator with its accumulator and result types
+// erased, which lets WindowOperator[IN] hold any aggregator of IN items.
+// User-defined functions are applied with panics recovered as *api.PanicError.
+type aggregation[IN any] struct {
+	initf   func() any
+	addf    func(acc any, item IN) any
+	mergef  func(acc1, acc2 any) any
+	resultf func(acc any) any
+}
+
+func newAggregation[IN, ACC, OUT any](agg funcs.Aggregator[IN, ACC, OUT]) *aggregation[IN] {
+	a := &aggregation[IN]{
+		initf: func() any {
+			if agg.Init == nil {
+				var acc ACC
+				return acc
+			}
+			return agg.Init()
+		},
+		addf: func(acc any, item IN) any {
+			v, _ := acc.(ACC)
+			return agg.Add(v, item)
+		},
+		resultf: func(acc any) any {
+			v, _ := acc.(ACC)
+			if agg.Result == nil {
+				result, _ := any(v).(OUT)
+				return result
+			}
+			return agg.Result(v)
+		},
+	}
+	if agg.Merge != nil {
+		a.mergef = func(acc1, acc2 any) any {
+			v1, _ := acc1.(ACC)
+			v2, _ := acc2.(ACC)
+			return agg.Merge(v1, v2)
+		}
+	}
+	return a
+}
+
+// add folds item into acc, or into a new accumulator if empty is true
+func (a *aggregation[IN]) add(acc any, empty bool, item IN) (any, error) {
+	return safely(item, func() any {
+		if empty {
+			acc = a.initf()
+		}
+		return a.addf(acc, item)
+	})
+}
+
+// value returns the aggregation result of pane p. The accumulators of sliding
+// windows are merged into a new accumulator to leave them unmodified.
+func (a *aggregation[IN]) value(p *pane[IN]) (any, error) {
+	if len(p.parts) > 1 && a.mergef == nil {
+		return nil, errNoMerge
+	}
+	return safely(nil, func() any {
+		acc := p.acc
+		switch {
+		case len(p.parts) == 1:
+			acc = p.parts[0]
+		case len(p.parts) > 1:
+			acc = a.initf()
+			for _, part := range p.parts {
+				acc = a.mergef(acc, part)
+			}
+		case p.count == 0:
+			acc = a.initf()
+		}
+		return a.resultf(acc)
+	})
+}
+
+// safely calls f, returning a panic raised by f as an *api.PanicError for item
+func safely(item any, f func() any) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.NewPanicError(r, item)
+		}
+	}()
+	return f(), nil
+}
+
+// merged returns a pane whose result merges the accumulators of panes
+func merged[IN any](panes []*pane[IN], start time.Time) *pane[IN] {
+	p := &pane[IN]{start: start}
+	for _, part := range panes {
+		p.parts = append(p.parts, part.acc)
+		p.count += part.count
+	}
+	return p
+}
+
+// timeSlice holds the accumulator of the items admitted during a slice of time
+type timeSlice[IN any] struct {
+	index int64 // slice index, slices start at epoch+(index-1)*length
+	pane  *pane[IN]
+}
+
+// slicedTimeAssigner is the slidingTimeAssigner of aggregated windows. Rather
+// than keeping items, it folds them into slices of time whose length evenly
+// divides both the window size and slide. Emitted windows merge the slices
+// they cover. Slices are aligned on epoch, the time the operator started,
+// since windows are emitted by a ticker started at that time.
+type slicedTimeAssigner[IN any] struct {
+	agg     *aggregation[IN]
+	epoch   time.Time
+	length  time.Duration // length of a slice
+	size    int64         // window size, in slices
+	slide   int64         // window slide, in slices
+	slices  []timeSlice[IN]
+	pending bool // items admitted since the last emitted pane
+}
+
+func newSlicedTimeAssigner[IN any](agg *aggregation[IN], epoch time.Time, size, slide time.Duration) *slicedTimeAssigner[IN] {
+	length := gcd(size, slide)
+	return &slicedTimeAssigner[IN]{
+		agg:    agg,
+		epoch:  epoch,
+		length: length,
+		size:   int64(size / length),
+		slide:  int64(slide / length),
+	}
+}
+
+func (a *slicedTimeAssigner[IN]) add(_ context.Context, adm admission[IN]) ([]*pane[IN], error) {
+	index := a.index(adm.time)
+	if len(a.slices) == 0 || a.slices[len(a.slices)-1].index != index {
+		a.slices = append(a.slices, timeSlice[IN]{index: index, pane: &pane[IN]{}})
+	}
+	last := a.slices[len(a.slices)-1].pane
+	if err := last.add(a.agg, adm.item); err != nil {
+		if last.count == 0 {
+			a.slices = a.slices[:len(a.slices)-1]
+		}
+		return nil, err
+	}
+	a.pending = true
+	return nil, nil
+}
+
+func (a *slicedTimeAssigner[IN]) tick(now time.Time) []*pane[IN] {
+	// round to the closest boundary to absorb ticker delays
+	last := int64((now.Sub(a.epoch) + a.length/2) / a.length)
+	p := a.pane(last, now).close(now, FireByTimer)
+
+	// evict slices before the emitted window, which are kept
+	// until the next tick for the window emitted by flush
+	i := 0
+	for i < len(a.slices) && a.slices[i].index <= last-a.size {
+		i++
+	}
+	a.slices = a.slices[i:]
+	a.pending = false
+	return []*pane[IN]{p}
+}
+
+func (a *slicedTimeAssigner[IN]) flush(now time.Time) []*pane[IN] {
+	if !a.pending || len(a.slices) == 0 {
+		return nil
+	}
+	return []*pane[IN]{a.pane(a.index(now), now).close(now, FireByClose)}
+}
+
+func (a *slicedTimeAssigner[IN]) idle() bool {
+	return len(a.slices) == 0
+}
+
+// index returns the index of the slice holding time t. Times at a slice
+// boundary belong to the slice ending at that boundary.
+func (a *slicedTimeAssigner[IN]) index(t time.Time) int64 {
+	elapsed := t.Sub(a.epoch)
+	index := int64(elapsed / a.length)
+	if elapsed > 0 && elapsed%a.length != 0 {
+		index++
+	}
+	return index
+}
+
+// pane returns a pane merging the slices of the window that ends with slice last
+func (a *slicedTimeAssigner[IN]) pane(last int64, now time.Time) *pane[IN] {
+	var panes []*pane[IN]
+	for _, slice := range a.slices {
+		if slice.index > last-a.size && slice.index <= last {
+			panes = append(panes, slice.pane)
+		}
+	}
+	return merged(panes, now.Add(-a.length*time.Duration(a.size)))
+}
+
+// slicedCountAssigner is the slidingCountAssigner of aggregated windows.
+// Rather than keeping items, it folds them into slices holding a number of
+// items that evenly divides both the window size and slide. Emitted windows
+// merge the last slices that make up the window size.
+type slicedCountAssigner[IN any] struct {
+	agg    *aggregation[IN]
+	length int // items per slice
+	size   int // window size, in slices
+	slide  int // window slide, in items
+	slices []*pane[IN]
+	since  int // items admitted since the last emitted pane
+}
+
+func newSlicedCountAssigner[IN any](agg *aggregation[IN], size, slide int) *slicedCountAssigner[IN] {
+	length := gcd(size, slide)
+	return &slicedCountAssigner[IN]{agg: agg, length: length, size: size / length, slide: slide}
+}
+
+func (a *slicedCountAssigner[IN]) add(_ context.Context, adm admission[IN]) ([]*pane[IN], error) {
+	if len(a.slices) == 0 || a.slices[len(a.slices)-1].count == a.length {
+		a.slices = append(a.slices, &pane[IN]{start: adm.time})
+	}
+	last := a.slices[len(a.slices)-1]
+	if err := last.add(a.agg, adm.item); err != nil {
+		if last.count == 0 {
+			a.slices = a.slices[:len(a.slices)-1]
+		}
+		return nil, err
+	}
+	if len(a.slices) > a.size {
+		a.slices = a.slices[len(a.slices)-a.size:]
+	}
+
+	a.since++
+	if a.since < a.slide {
+		return nil, nil
+	}
+	a.since = 0
+	closed := merged(a.slices, a.slices[0].start).close(adm.time, FireByCount)
+	// only the slices that overlap the next window are kept
+	keep := max(a.size-a.slide/a.length, 0)
+	a.slices = a.slices[len(a.slices)-min(keep, len(a.slices)):]
+	return []*pane[IN]{closed}, nil
+}
+
+func (a *slicedCountAssigner[IN]) tick(time.Time) []*pane[IN] {
+	return nil
+}
+
+// flush returns the partial window that would have been emitted at the
+// next slide, merging the slices that hold the items of that window.
+func (a *slicedCountAssigner[IN]) flush(now time.Time) []*pane[IN] {
+	if a.since == 0 {
+		return nil
+	}
+	n := a.size*a.length - (a.slide - a.since)
+	i := len(a.slices)
+	for i > 0 && n > 0 {
+		i--
+		n -= a.slices[i].count
+	}
+	if i == len(a.slices) {
+		return nil
+	}
+	return []*pane[IN]{merged(a.slices[i:], a.slices[i].start).close(now, FireByClose)}
+}
+
+func (a *slicedCountAssigner[IN]) idle() bool {
+	return len(a.slices) == 0
+}
+
+// gcd returns the greatest common divisor of a and b
+func gcd[T ~int | ~int64](a, b T) T {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,217 @@
+package window
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/api/tuple"
+	"github.com/vladimirvivien/automi/funcs"
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+// sendSlidingDuration sends items to a sliding duration window over a fake clock
+func sendSlidingDuration(o *WindowOperator[int]) func(chan<- any) {
+	clock := testutil.NewFakeClock()
+	o.SetClock(clock)
+	return func(in chan<- any) {
+		for i := 1; i <= 20; i++ {
+			clock.Send(in, i)
+			clock.Advance(time.Duration(i%4) * time.Second)
+		}
+	}
+}
+
+func TestWindowAggregate(t *testing.T) {
+	sum := funcs.SumAggregator[int]()
+	byChar := func(s string) byte { return s[0] }
+
+	clock := testutil.NewFakeClock()
+	byDuration := Aggregate(ByDuration[string](5*time.Second), funcs.CountAggregator[string]())
+	byDuration.SetClock(clock)
+	byDuration.SetEmitEmpty(true)
+
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	byEventTime := Aggregate(
+		BySlidingEventTime(10*time.Minute, 5*time.Minute, func(at time.Duration) time.Time { return base.Add(at) }),
+		funcs.CountAggregator[time.Duration](),
+	)
+	byEventTime.SetEmitMetadata(true)
+
+	tests := []struct {
+		name     string
+		op       api.Operator
+		send     func(in chan<- any)
+		format   func(data any) string
+		expected string
+	}{
+		{
+			name:     "by size",
+			op:       Aggregate(BySize[int](3), sum),
+			send:     testutil.SendItems(1, 2, 3, 4, 5, 6, 7),
+			expected: "[6 15 7]",
+		},
+		{
+			name: "by duration",
+			op:   byDuration,
+			send: func(in chan<- any) {
+				clock.Send(in, "A")
+				clock.Send(in, "B")
+				clock.Advance(5 * time.Second) // closes [A B]
+				clock.Advance(5 * time.Second) // closes empty window
+				clock.Send(in, "C")
+			},
+			expected: "[2 0 1]",
+		},
+		{
+			name: "keyed",
+			op:   KeyedAggregate(BySize[string](2), byChar, funcs.CountAggregator[string]()),
+			send: testutil.SendItems("a1", "b1", "a2", "b2", "b3"),
+			format: func(data any) string {
+				count := data.(tuple.Pair[byte, uint64])
+				return fmt.Sprintf("%c:%d", count.Val1, count.Val2)
+			},
+			expected: "[a:2 b:2 b:1]",
+		},
+		{
+			// ACC is not OUT: results are the zero OUT rather than a panic
+			name: "keyed without result func",
+			op: KeyedAggregate(BySize[string](2), byChar, funcs.Aggregator[string, int, string]{
+				Add: func(acc int, _ string) int { return acc + 1 },
+			}),
+			send: testutil.SendItems("a1", "a2"),
+			format: func(data any) string {
+				result := data.(tuple.Pair[byte, string])
+				return fmt.Sprintf("%c:%q", result.Val1, result.Val2)
+			},
+			expected: `[a:""]`,
+		},
+		{
+			name: "event time with metadata",
+			op:   byEventTime,
+			send: testutil.SendItems(1*time.Minute, 6*time.Minute, 7*time.Minute, 16*time.Minute),
+			format: func(data any) string {
+				w := data.(Window[time.Duration])
+				if w.Items != nil {
+					return fmt.Sprint("unexpected items ", w.Items)
+				}
+				return fmt.Sprintf("%s:%v", w.Start.Format("15:04"), w.Result)
+			},
+			expected: "[11:55:1 12:00:3 12:05:2 12:10:1 12:15:1]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var results []string
+			for _, data := range outputs(t, test.op, test.send) {
+				if test.format != nil {
+					results = append(results, test.format(data))
+					continue
+				}
+				results = append(results, fmt.Sprint(data))
+			}
+			if fmt.Sprint(results) != test.expected {
+				t.Fatal("unexpected results:", results)
+			}
+		})
+	}
+
+	t.Run("sliding windows match item windows", func(t *testing.T) {
+		sumOf := func(windows []any) []any {
+			var sums []any
+			for _, w := range windows {
+				total := 0.0
+				for _, i := range w.([]int) {
+					total += float64(i)
+				}
+				sums = append(sums, total)
+			}
+			return sums
+		}
+
+		items := testutil.SendItems(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11)
+		for _, test := range []struct{ size, slide uint64 }{{4, 2}, {3, 3}, {2, 3}, {6, 4}} {
+			expected := sumOf(outputs(t, BySlidingSize[int](test.size, test.slide), items))
+			actual := outputs(t, Aggregate(BySlidingSize[int](test.size, test.slide), sum), items)
+			if fmt.Sprint(actual) != fmt.Sprint(expected) {
+				t.Errorf("size %d, slide %d: expected %v, got %v", test.size, test.slide, expected, actual)
+			}
+		}
+
+		for _, test := range []struct{ size, slide time.Duration }{
+			{10 * time.Second, 5 * time.Second},
+			{3 * time.Second, 5 * time.Second},
+			{10 * time.Second, 4 * time.Second},
+		} {
+			items := BySlidingDuration[int](test.size, test.slide)
+			expected := sumOf(outputs(t, items, sendSlidingDuration(items)))
+			aggregated := Aggregate(BySlidingDuration[int](test.size, test.slide), sum)
+			actual := outputs(t, aggregated, sendSlidingDuration(aggregated))
+			if fmt.Sprint(actual) != fmt.Sprint(expected) {
+				t.Errorf("size %v, slide %v: expected %v, got %v", test.size, test.slide, expected, actual)
+			}
+		}
+	})
+
+	t.Run("keyed and aggregate combined", func(t *testing.T) {
+		key := func(s string) byte { return s[0] }
+		tests := []struct {
+			name string
+			op   *WindowOperator[string]
+		}{
+			{name: "keyed aggregate", op: Keyed(Aggregate(BySize[string](2), funcs.CountAggregator[string]()), key)},
+			{name: "aggregate keyed", op: Aggregate(Keyed(BySize[string](2), key), funcs.CountAggregator[string]())},
+		}
+		for _, test := range tests {
+			test.op.SetInput(make(chan any))
+			if err := test.op.Exec(context.TODO()); !errors.Is(err, errKeyedAggregate) {
+				t.Fatalf("%s: expecting KeyedAggregate error, got: %v", test.name, err)
+			}
+		}
+	})
+
+	t.Run("aggregator errors", func(t *testing.T) {
+		agg := funcs.Aggregator[int, int, int]{
+			Add: func(acc, item int) int {
+				if item == 2 {
+					panic("bad item")
+				}
+				return acc + item
+			},
+		}
+		o := Aggregate(BySlidingSize[int](4, 2), agg)
+		o.SetErrorPolicy(api.ErrorPolicyReroute)
+		errs := o.GetSideOutput(api.ErrorOutput)
+		in := make(chan any)
+		go func() {
+			for i := 1; i <= 4; i++ {
+				in <- i
+			}
+			close(in)
+		}()
+		o.SetInput(in)
+		if err := o.Exec(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+
+		var results []any
+		for data := range o.GetOutput() {
+			results = append(results, data)
+		}
+		var failed []any
+		for data := range errs {
+			failed = append(failed, data.(api.StreamResult).Value)
+		}
+		// window [1 3] has a single slice, window [1 3 4] needs Merge
+		if fmt.Sprint(results) != "[4]" {
+			t.Fatal("unexpected results:", results)
+		}
+		if fmt.Sprint(failed) != "[2 <nil>]" {
+			t.Fatal("unexpected failed items:", failed)
+		}
+	})
+}
@@ -0,0 +1,474 @@
+package window
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+// admission is an item admitted by the window operator
+type admission[IN any] struct {
+	item     IN
+	time     time.Time // time the item was admitted
+	received uint64    // items received by the operator, including this one
+	key      any       // key of the item, for keyed windows
+}
+
+// pane holds the items of a window until the window is emitted. The items
+// of aggregated windows are folded into an accumulator instead.
+type pane[IN any] struct {
+	key    any // set for keyed windows
+	items  []IN
+	acc    any   // accumulator of aggregated windows
+	parts  []any // accumulators merged into the result of aggregated sliding windows
+	count  int   // number of items in the window
+	bytes  int   // size of the items, for windows bounded by size
+	sizes  []int // size of each item, for windows bounded by size
+	start  time.Time
+	end    time.Time
+	reason FireReason
+}
+
+// add adds item to the pane, or folds it into the pane accumulator if agg is set
+func (p *pane[IN]) add(agg *aggregation[IN], item IN) error {
+	if agg == nil {
+		p.items = append(p.items, item)
+		p.count++
+		return nil
+	}
+	acc, err := agg.add(p.acc, p.count == 0, item)
+	if err != nil {
+		return err
+	}
+	p.acc = acc
+	p.count++
+	return nil
+}
+
+// trim removes and returns the n oldest items of the pane
+func (p *pane[IN]) trim(n int) []IN {
+	removed := p.items[:n:n]
+	p.items = p.items[n:]
+	p.count -= n
+	if p.sizes != nil {
+		for _, size := range p.sizes[:n] {
+			p.bytes -= size
+		}
+		p.sizes = p.sizes[n:]
+	}
+	return removed
+}
+
+// close sets the end time of the pane, unless already set
+// as with event-time windows, and the reason it fired
+func (p *pane[IN]) close(end time.Time, reason FireReason) *pane[IN] {
+	if p.end.IsZero() {
+		p.end = end
+	}
+	p.reason = reason
+	return p
+}
+
+// windowAssigner assigns admitted items to panes and decides when panes
+// close. The window operator emits closed panes downstream. Assigners are
+// only used from the operator goroutine and need no synchronization.
+type windowAssigner[IN any] interface {
+	// add admits an item and returns the panes closed by its arrival
+	add(ctx context.Context, adm admission[IN]) ([]*pane[IN], error)
+	// tick returns the panes closed at the specified time
+	tick(now time.Time) []*pane[IN]
+	// flush returns the panes still open when the operator input is closed
+	flush(now time.Time) []*pane[IN]
+	// idle reports whether the assigner holds no item
+	idle() bool
+}
+
+// triggerAssigner collects items into consecutive, non-overlapping (tumbling)
+// panes. A pane closes when the trigger function returns true or on tick.
+type triggerAssigner[IN any] struct {
+	trigger       TriggerFunction[IN]
+	agg           *aggregation[IN]
+	bounds        *bounds[IN]
+	operatorStart time.Time
+	current       *pane[IN]
+}
+
+func newTriggerAssigner[IN any](trigger TriggerFunction[IN], agg *aggregation[IN], bounds *bounds[IN], start time.Time) *triggerAssigner[IN] {
+	if trigger == nil {
+		trigger = TriggerAllFunc[IN]()
+	}
+	return &triggerAssigner[IN]{
+		trigger:       trigger,
+		agg:           agg,
+		bounds:        bounds,
+		operatorStart: start,
+		current:       &pane[IN]{start: start},
+	}
+}
+
+func (a *triggerAssigner[IN]) add(ctx context.Context, adm admission[IN]) ([]*pane[IN], error) {
+	size, ok, err := a.bounds.reserve(a.current, adm.item)
+	if err != nil {
+		return nil, err
+	}
+	var closed []*pane[IN]
+	if !ok {
+		if a.bounds.overflow == OverflowDropNewest {
+			return nil, nil
+		}
+		closed = append(closed, a.current.close(adm.time, FireByOverflow))
+		a.current = &pane[IN]{start: adm.time}
+	}
+
+	done, err := applyTrigger(ctx, a.trigger, WindowContext[IN]{
+		OperatorStartTime: a.operatorStart,
+		OperatorItemCount: adm.received,
+		WindowStartTime:   a.current.start,
+		WindowItemCount:   uint64(a.current.count + 1),
+		Item:              adm.item,
+		ItemWindowTime:    adm.time,
+		Key:               adm.key,
+	})
+	if err != nil {
+		return closed, err
+	}
+	if err := a.current.add(a.agg, adm.item); err != nil {
+		return closed, err
+	}
+	a.bounds.added(a.current, size)
+	if !done {
+		return closed, nil
+	}
+
+	closed = append(closed, a.current.close(adm.time, FireByTrigger))
+	a.current = &pane[IN]{start: adm.time}
+	return closed, nil
+}
+
+func (a *triggerAssigner[IN]) tick(now time.Time) []*pane[IN] {
+	closed := a.current.close(now, FireByTimer)
+	a.current = &pane[IN]{start: now}
+	return []*pane[IN]{closed}
+}
+
+func (a *triggerAssigner[IN]) flush(now time.Time) []*pane[IN] {
+	if a.current.count == 0 {
+		return nil
+	}
+	return []*pane[IN]{a.current.close(now, FireByClose)}
+}
+
+func (a *triggerAssigner[IN]) idle() bool {
+	return a.current.count == 0
+}
+
+// applyTrigger applies the trigger function to wctx. A panic raised by the
+// trigger is recovered and returned as an *api.PanicError.
+func applyTrigger[IN any](ctx context.Context, trigger TriggerFunction[IN], wctx WindowContext[IN]) (done bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.NewPanicError(r, wctx.Item)
+		}
+	}()
+	return trigger(ctx, wctx), nil
+}
+
+// slidingTimeAssigner keeps the items admitted during the last size duration.
+// On each tick, it emits a pane with those items, which means an item belongs
+// to every window emitted while it is younger than size. Since windows
+// overlap, bounded windows drop their oldest items rather than firing early.
+type slidingTimeAssigner[IN any] struct {
+	size    time.Duration
+	bounds  *bounds[IN]
+	items   pane[IN]    // items admitted during the last size duration
+	times   []time.Time // admission time of each item
+	pending bool        // items admitted since the last emitted pane
+}
+
+func (a *slidingTimeAssigner[IN]) add(_ context.Context, adm admission[IN]) ([]*pane[IN], error) {
+	a.evict(adm.time)
+	size, ok, err := a.bounds.reserve(&a.items, adm.item)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if a.bounds.overflow == OverflowDropNewest {
+			return nil, nil
+		}
+		a.bounds.dropOldest(&a.items, size)
+	}
+	a.times = a.times[len(a.times)-a.items.count:]
+
+	a.items.add(nil, adm.item)
+	a.bounds.added(&a.items, size)
+	a.times = append(a.times, adm.time)
+	a.pending = true
+	return nil, nil
+}
+
+func (a *slidingTimeAssigner[IN]) tick(now time.Time) []*pane[IN] {
+	a.evict(now)
+	a.pending = false
+	return []*pane[IN]{a.pane(now).close(now, FireByTimer)}
+}
+
+func (a *slidingTimeAssigner[IN]) flush(now time.Time) []*pane[IN] {
+	if !a.pending || a.items.count == 0 {
+		return nil
+	}
+	return []*pane[IN]{a.pane(now).close(now, FireByClose)}
+}
+
+func (a *slidingTimeAssigner[IN]) idle() bool {
+	return a.items.count == 0
+}
+
+// evict removes items that fell out of the window ending at now
+func (a *slidingTimeAssigner[IN]) evict(now time.Time) {
+	start := now.Add(-a.size)
+	i := 0
+	for i < len(a.times) && !a.times[i].After(start) {
+		i++
+	}
+	a.items.trim(i)
+	a.times = a.times[i:]
+}
+
+// pane returns a copy of the window ending at now
+func (a *slidingTimeAssigner[IN]) pane(now time.Time) *pane[IN] {
+	items := slices.Clone(a.items.items)
+	return &pane[IN]{items: items, count: len(items), start: now.Add(-a.size)}
+}
+
+// slidingCountAssigner keeps the last size items admitted and emits a pane
+// with those items every slide items. Once a pane is emitted, only the items
+// that overlap the next window are kept. Since windows overlap, bounded
+// windows drop their oldest items rather than firing early.
+type slidingCountAssigner[IN any] struct {
+	size   int
+	slide  int
+	bounds *bounds[IN]
+	items  pane[IN]    // items of the next window
+	start  []time.Time // admission time of each kept item
+	since  int         // items admitted since the last emitted pane
+}
+
+func (a *slidingCountAssigner[IN]) add(_ context.Context, adm admission[IN]) ([]*pane[IN], error) {
+	size, ok, err := a.bounds.reserve(&a.items, adm.item)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if a.bounds.overflow == OverflowDropNewest {
+			return nil, nil
+		}
+		a.bounds.dropOldest(&a.items, size)
+	}
+	a.start = a.start[len(a.start)-a.items.count:]
+
+	a.items.add(nil, adm.item)
+	a.bounds.added(&a.items, size)
+	a.start = append(a.start, adm.time)
+	a.keep(a.size)
+
+	a.since++
+	if a.since < a.slide {
+		return nil, nil
+	}
+	a.since = 0
+	closed := a.pane().close(adm.time, FireByCount)
+	a.keep(a.size - a.slide)
+	return []*pane[IN]{closed}, nil
+}
+
+func (a *slidingCountAssigner[IN]) tick(time.Time) []*pane[IN] {
+	return nil
+}
+
+// flush returns the partial window that would have been emitted at the next
+// slide, holding only the items of that window that were received.
+func (a *slidingCountAssigner[IN]) flush(now time.Time) []*pane[IN] {
+	if a.since == 0 {
+		return nil
+	}
+	a.keep(a.size - (a.slide - a.since))
+	if a.items.count == 0 {
+		return nil
+	}
+	return []*pane[IN]{a.pane().close(now, FireByClose)}
+}
+
+func (a *slidingCountAssigner[IN]) idle() bool {
+	return a.items.count == 0
+}
+
+// keep removes the oldest items, which fell out of the window, until n are left
+func (a *slidingCountAssigner[IN]) keep(n int) {
+	if n := a.items.count - max(n, 0); n > 0 {
+		a.items.trim(n)
+		a.start = a.start[n:]
+	}
+}
+
+// pane returns a copy of the items kept
+func (a *slidingCountAssigner[IN]) pane() *pane[IN] {
+	items := slices.Clone(a.items.items)
+	return &pane[IN]{items: items, count: len(items), start: a.start[0]}
+}
+
+// sessionAssigner collects items into a session pane that closes once no
+// item is admitted for the gap duration. A session is closed either by the
+// first item that arrives after the gap or on tick.
+type sessionAssigner[IN any] struct {
+	gap     time.Duration
+	agg     *aggregation[IN]
+	bounds  *bounds[IN]
+	current *pane[IN]
+	last    time.Time // admission time of the last item of the session
+}
+
+func (a *sessionAssigner[IN]) add(_ context.Context, adm admission[IN]) ([]*pane[IN], error) {
+	var closed []*pane[IN]
+	if a.current != nil && adm.time.Sub(a.last) >= a.gap {
+		closed = append(closed, a.current.close(adm.time, FireBySessionGap))
+		a.current = nil
+	}
+	current := a.current
+	if current == nil {
+		current = &pane[IN]{start: adm.time}
+	}
+	size, ok, err := a.bounds.reserve(current, adm.item)
+	if err != nil {
+		return closed, err
+	}
+	if !ok {
+		if a.bounds.overflow == OverflowDropNewest {
+			return closed, nil
+		}
+		closed = append(closed, current.close(adm.time, FireByOverflow))
+		current = &pane[IN]{start: adm.time}
+		a.current = current
+	}
+	if err := current.add(a.agg, adm.item); err != nil {
+		return closed, err
+	}
+	a.bounds.added(current, size)
+	a.current = current
+	a.last = adm.time
+	return closed, nil
+}
+
+func (a *sessionAssigner[IN]) tick(now time.Time) []*pane[IN] {
+	if a.current == nil || now.Sub(a.last) < a.gap {
+		return nil
+	}
+	closed := a.current.close(now, FireBySessionGap)
+	a.current = nil
+	return []*pane[IN]{closed}
+}
+
+func (a *sessionAssigner[IN]) flush(now time.Time) []*pane[IN] {
+	if a.current == nil {
+		return nil
+	}
+	return []*pane[IN]{a.current.close(now, FireByClose)}
+}
+
+func (a *sessionAssigner[IN]) idle() bool {
+	return a.current == nil
+}
+
+// keyedAssigner partitions items by key, with each key getting its own
+// assigner and, therefore, its own window state. The state of a key is
+// released once its assigner is idle after a tick or after a window closes.
+type keyedAssigner[IN any] struct {
+	key      func(IN) any
+	assigner func(start time.Time) windowAssigner[IN]
+	keys     map[any]*keyedState[IN]
+	seq      uint64
+}
+
+// keyedState is the window state of a key
+type keyedState[IN any] struct {
+	key      any
+	seq      uint64 // orders keys by creation
+	assigner windowAssigner[IN]
+}
+
+func newKeyedAssigner[IN any](key func(IN) any, assigner func(time.Time) windowAssigner[IN]) *keyedAssigner[IN] {
+	return &keyedAssigner[IN]{key: key, assigner: assigner, keys: make(map[any]*keyedState[IN])}
+}
+
+func (a *keyedAssigner[IN]) add(ctx context.Context, adm admission[IN]) ([]*pane[IN], error) {
+	key, err := apply(a.key, adm.item)
+	if err != nil {
+		return nil, err
+	}
+	adm.key = key
+	state, ok := a.keys[key]
+	if !ok {
+		a.seq++
+		state = &keyedState[IN]{key: key, seq: a.seq, assigner: a.assigner(adm.time)}
+		a.keys[key] = state
+	}
+
+	closed, err := state.assigner.add(ctx, adm)
+	return a.release(state, closed), err
+}
+
+func (a *keyedAssigner[IN]) tick(now time.Time) []*pane[IN] {
+	var closed []*pane[IN]
+	for _, state := range a.states() {
+		closed = append(closed, a.release(state, state.assigner.tick(now))...)
+	}
+	return closed
+}
+
+func (a *keyedAssigner[IN]) flush(now time.Time) []*pane[IN] {
+	var closed []*pane[IN]
+	for _, state := range a.states() {
+		closed = append(closed, a.release(state, state.assigner.flush(now))...)
+	}
+	return closed
+}
+
+func (a *keyedAssigner[IN]) idle() bool {
+	return len(a.keys) == 0
+}
+
+// release labels panes with the key and drops the key state if it is idle
+func (a *keyedAssigner[IN]) release(state *keyedState[IN], panes []*pane[IN]) []*pane[IN] {
+	for _, p := range panes {
+		p.key = state.key
+	}
+	if state.assigner.idle() {
+		delete(a.keys, state.key)
+	}
+	return panes
+}
+
+// states returns the key states in creation order
+func (a *keyedAssigner[IN]) states() []*keyedState[IN] {
+	states := make([]*keyedState[IN], 0, len(a.keys))
+	for _, state := range a.keys {
+		states = append(states, state)
+	}
+	slices.SortFunc(states, func(s1, s2 *keyedState[IN]) int {
+		return cmp.Compare(s1.seq, s2.seq)
+	})
+	return states
+}
+
+// apply applies a user-defined function, such as a key or timestamp function,
+// to item. A panic raised by f is recovered and returned as an *api.PanicError.
+func apply[IN, OUT any](f func(IN) OUT, item IN) (result OUT, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.NewPanicError(r, item)
+		}
+	}()
+	return f(item), nil
+}
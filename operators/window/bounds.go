@@ -0,0 +1,87 @@
+package window
+
+// DroppedOutput is the name of the side output that receives items dropped
+// from full windows. See SetMaxItems and SetMaxBytes.
+const DroppedOutput = "dropped"
+
+// OverflowPolicy specifies how a window operator handles an item that
+// does not fit in its window, see WindowOperator.SetOverflowPolicy
+type OverflowPolicy uint8
+
+const (
+	OverflowFireEarly  OverflowPolicy = iota // Emit the full window and add the item to a new window
+	OverflowDropOldest                       // Drop the oldest items of the window to make room for the item
+	OverflowDropNewest                       // Drop the item
+)
+
+// bounds limits the items held by the panes of a window operator
+type bounds[IN any] struct {
+	maxItems int
+	maxBytes int
+	size     func(IN) int
+	overflow OverflowPolicy
+	dropped  []IN // items dropped since last drained by the operator
+}
+
+// enabled reports whether panes are bounded
+func (b *bounds[IN]) enabled() bool {
+	return b != nil && (b.maxItems > 0 || b.maxBytes > 0)
+}
+
+// reserve makes room in p for item according to the overflow policy and
+// returns the size of item. It returns false when item does not fit and
+// either p must be emitted before item is added (OverflowFireEarly) or item
+// must be dropped (OverflowDropNewest). Empty panes always accept an item.
+func (b *bounds[IN]) reserve(p *pane[IN], item IN) (size int, ok bool, err error) {
+	if !b.enabled() {
+		return 0, true, nil
+	}
+	if b.maxBytes > 0 {
+		if size, err = apply(b.size, item); err != nil {
+			return 0, false, err
+		}
+	}
+	if b.fits(p, size) {
+		return size, true, nil
+	}
+
+	switch b.overflow {
+	case OverflowDropOldest:
+		b.dropOldest(p, size)
+		return size, true, nil
+	case OverflowDropNewest:
+		b.dropped = append(b.dropped, item)
+	}
+	return size, false, nil
+}
+
+// dropOldest drops the oldest items of p until an item of size fits
+func (b *bounds[IN]) dropOldest(p *pane[IN], size int) {
+	for p.count > 0 && !b.fits(p, size) {
+		b.dropped = append(b.dropped, p.trim(1)...)
+	}
+}
+
+// fits reports whether an item of size can be added to p
+func (b *bounds[IN]) fits(p *pane[IN], size int) bool {
+	if p.count == 0 {
+		return true
+	}
+	return (b.maxItems <= 0 || p.count < b.maxItems) &&
+		(b.maxBytes <= 0 || p.bytes+size <= b.maxBytes)
+}
+
+// added records the size, returned by reserve, of the item added to p
+func (b *bounds[IN]) added(p *pane[IN], size int) {
+	if b.enabled() {
+		p.bytes += size
+		p.sizes = append(p.sizes, size)
+	}
+}
+
+// drain returns and clears the dropped items
+func (b *bounds[IN]) drain() []IN {
+	dropped := b.dropped
+	b.dropped = nil
+	return dropped
+}
@@ -0,0 +1,155 @@
+package window
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+func TestWindowBounds(t *testing.T) {
+	run := func(t *testing.T, o *WindowOperator[string], items ...string) (windows, dropped []string) {
+		o.SetEmitMetadata(true)
+		droppedOutput := o.GetSideOutput(DroppedOutput)
+		testutil.RunOperatorTest(t, testutil.OperatorTest{
+			Operator: o,
+			Send:     testutil.SendItems(items...),
+			Tester: func(t *testing.T, out <-chan any) {
+				for data := range out {
+					w := data.(Window[string])
+					windows = append(windows, fmt.Sprintf("%v:%s", w.Items, w.Reason))
+				}
+				for data := range droppedOutput {
+					dropped = append(dropped, data.(string))
+				}
+			},
+		})
+		return windows, dropped
+	}
+	items := []string{"a", "b", "c", "d", "e", "f", "g"}
+
+	tests := []struct {
+		name     string
+		window   func() *WindowOperator[string]
+		policy   OverflowPolicy
+		windows  string
+		dropped  string
+		maxItems int
+		maxBytes int
+	}{
+		{
+			name:     "fire early",
+			window:   func() *WindowOperator[string] { return BySize[string](3) },
+			policy:   OverflowFireEarly,
+			maxItems: 2,
+			windows:  "[[a b]:overflow [c d]:overflow [e f]:overflow [g]:close]",
+			dropped:  "[]",
+		},
+		{
+			name:     "drop oldest",
+			window:   Batch[string],
+			policy:   OverflowDropOldest,
+			maxItems: 3,
+			windows:  "[[e f g]:close]",
+			dropped:  "[a b c d]",
+		},
+		{
+			name:     "drop newest",
+			window:   Batch[string],
+			policy:   OverflowDropNewest,
+			maxItems: 3,
+			windows:  "[[a b c]:close]",
+			dropped:  "[d e f g]",
+		},
+		{
+			name:     "max bytes",
+			window:   func() *WindowOperator[string] { return BySize[string](3) },
+			policy:   OverflowFireEarly,
+			maxBytes: 6,
+			windows:  "[[a b c]:trigger [d e f]:trigger [g]:close]",
+			dropped:  "[]",
+		},
+		{
+			name:     "sliding windows drop oldest",
+			window:   func() *WindowOperator[string] { return BySlidingDuration[string](time.Hour, time.Hour) },
+			policy:   OverflowFireEarly,
+			maxItems: 2,
+			windows:  "[[f g]:close]",
+			dropped:  "[a b c d e]",
+		},
+		{
+			name:     "sliding size drop oldest",
+			window:   func() *WindowOperator[string] { return BySlidingSize[string](4, 2) },
+			policy:   OverflowFireEarly,
+			maxItems: 3,
+			windows:  "[[a b]:count [b c d]:count [d e f]:count [e f g]:close]",
+			dropped:  "[a c]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := test.window()
+			o.SetMaxItems(test.maxItems)
+			o.SetMaxBytes(test.maxBytes, func(s string) int { return 2 })
+			o.SetOverflowPolicy(test.policy)
+			windows, dropped := run(t, o, items...)
+			if fmt.Sprint(windows) != test.windows {
+				t.Error("unexpected windows:", windows)
+			}
+			if fmt.Sprint(dropped) != test.dropped {
+				t.Error("unexpected dropped items:", dropped)
+			}
+		})
+	}
+
+	t.Run("max bytes with large items", func(t *testing.T) {
+		o := Batch[string]()
+		o.SetMaxBytes(6, func(s string) int { return len(s) })
+		windows, _ := run(t, o, "abc", "defghij", "k", "lm", "no")
+		if fmt.Sprint(windows) != "[[abc]:overflow [defghij]:overflow [k lm no]:close]" {
+			t.Fatal("unexpected windows:", windows)
+		}
+	})
+
+	t.Run("evictors", func(t *testing.T) {
+		o := BySize[string](3)
+		o.SetEvictors(
+			func(_ context.Context, s string) bool { return s == "b" },
+			func(_ context.Context, s string) bool { return s > "e" },
+		)
+		windows, _ := run(t, o, items...)
+		if fmt.Sprint(windows) != "[[a c]:trigger [d e]:trigger]" {
+			t.Fatal("unexpected windows:", windows)
+		}
+	})
+}
+
+func TestWindowBySlidingSize_KeyedRelease(t *testing.T) {
+	tests := []struct {
+		name        string
+		size, slide int
+		keys        int
+	}{
+		{name: "tumbling windows", size: 2, slide: 2, keys: 0},
+		{name: "hopping windows", size: 2, slide: 3, keys: 0},
+		{name: "overlapping windows", size: 4, slide: 2, keys: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := newKeyedAssigner(func(int) any { return "key" }, func(time.Time) windowAssigner[int] {
+				return &slidingCountAssigner[int]{size: test.size, slide: test.slide}
+			})
+			for i := range 2 * test.slide {
+				if _, err := a.add(context.TODO(), admission[int]{item: i}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(a.keys) != test.keys {
+				t.Fatal("unexpected key states:", len(a.keys))
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package window
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+func TestWindowByDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		emitEmpty bool
+		expected  string
+	}{
+		{name: "skip empty windows", expected: "[[A B] [C] [D]]"},
+		{name: "emit empty windows", emitEmpty: true, expected: "[[A B] [] [C] [D]]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := testutil.NewFakeClock()
+			o := ByDuration[string](5 * time.Second)
+			o.SetClock(clock)
+			o.SetEmitEmpty(test.emitEmpty)
+
+			windows := outputs(t, o, func(in chan<- any) {
+				clock.Send(in, "A")
+				clock.Advance(2 * time.Second)
+				clock.Send(in, "B")
+				clock.Advance(3 * time.Second) // closes [A B]
+				clock.Advance(5 * time.Second) // closes empty window
+				clock.Send(in, "C")
+				clock.Advance(5 * time.Second) // closes [C] without new items
+				clock.Send(in, "D")
+			}) // closing the input flushes [D]
+			if fmt.Sprint(windows) != test.expected {
+				t.Fatal("unexpected windows:", windows)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package window
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+// LateOutput is the name of the side output that receives items that arrive
+// after all of their event-time windows expired. See SetAllowedLateness.
+const LateOutput = "late"
+
+// errLateItem is returned by an assigner for an item that is too late
+// to be assigned to any window
+var errLateItem = errors.New("late item")
+
+// errGapItem is returned by an assigner for an item that falls in the gap
+// between two hopping windows
+var errGapItem = errors.New("item between windows")
+
+// eventWindow is an event-time window along with its firing state
+type eventWindow[IN any] struct {
+	pane    *pane[IN]
+	fired   bool // the window was emitted at least once
+	updated bool // items were added since the window was last emitted
+}
+
+// eventTimeAssigner assigns items to event-time windows using the timestamp
+// of each item. Windows start at multiples of slide and last size, which means
+// items belong to size/slide overlapping windows (one when tumbling).
+//
+// Progress is tracked with a watermark that trails the largest timestamp
+// seen by outOfOrder: items are assumed to arrive at most outOfOrder late.
+// A window is emitted once the watermark passes its end. It is then kept for
+// the allowed lateness: late items added to it cause the whole window to be
+// emitted again. Items whose windows were all discarded are late items.
+type eventTimeAssigner[IN any] struct {
+	size       time.Duration
+	slide      time.Duration
+	outOfOrder time.Duration
+	lateness   time.Duration
+	timestamp  func(IN) time.Time
+	agg        *aggregation[IN]
+	bounds     *bounds[IN]
+
+	watermark    time.Time
+	hasWatermark bool
+	windows      map[int64]*eventWindow[IN] // windows by start time
+}
+
+func (a *eventTimeAssigner[IN]) add(_ context.Context, adm admission[IN]) ([]*pane[IN], error) {
+	ts, err := apply(a.timestamp, adm.item)
+	if err != nil {
+		return nil, err
+	}
+
+	// assign item to every window containing ts that is not discarded
+	var early []*pane[IN]
+	assigned, late := false, false
+	for start := ts.Truncate(a.slide); ts.Sub(start) < a.size; start = start.Add(-a.slide) {
+		end := start.Add(a.size)
+		if a.hasWatermark && !a.watermark.Before(end.Add(a.lateness)) {
+			late = true
+			continue
+		}
+		window, ok := a.windows[start.UnixNano()]
+		if !ok {
+			window = &eventWindow[IN]{pane: &pane[IN]{start: start, end: end}}
+			a.windows[start.UnixNano()] = window
+		}
+		size, ok, err := a.bounds.reserve(window.pane, adm.item)
+		if err != nil {
+			return early, err
+		}
+		if !ok {
+			if a.bounds.overflow == OverflowDropNewest {
+				assigned = true
+				continue
+			}
+			// emit the items of the window so far, the window stays open
+			p := *window.pane
+			early = append(early, p.close(p.end, FireByOverflow))
+			window.pane.items, window.pane.sizes = nil, nil
+			window.pane.count, window.pane.bytes = 0, 0
+		}
+		if err := window.pane.add(a.agg, adm.item); err != nil {
+			if window.pane.count == 0 {
+				delete(a.windows, start.UnixNano())
+			}
+			return early, err
+		}
+		a.bounds.added(window.pane, size)
+		window.updated = true
+		assigned = true
+	}
+
+	// advance watermark
+	if watermark := ts.Add(-a.outOfOrder); !a.hasWatermark || watermark.After(a.watermark) {
+		a.watermark = watermark
+		a.hasWatermark = true
+	}
+
+	closed := append(early, a.fire(false)...)
+	switch {
+	case late && !assigned:
+		return closed, errLateItem
+	case !late && !assigned:
+		return closed, errGapItem
+	}
+	return closed, nil
+}
+
+func (a *eventTimeAssigner[IN]) tick(time.Time) []*pane[IN] {
+	return nil
+}
+
+func (a *eventTimeAssigner[IN]) flush(time.Time) []*pane[IN] {
+	return a.fire(true)
+}
+
+func (a *eventTimeAssigner[IN]) idle() bool {
+	return len(a.windows) == 0
+}
+
+// fire returns, ordered by start time, copies of the updated windows that
+// ended before the watermark, or all updated windows if final. Windows past
+// the allowed lateness are discarded.
+func (a *eventTimeAssigner[IN]) fire(final bool) []*pane[IN] {
+	var closed []*pane[IN]
+	for start, window := range a.windows {
+		end := window.pane.end
+		if !final && end.After(a.watermark) {
+			continue
+		}
+		if window.updated {
+			p := *window.pane
+			p.items = slices.Clone(p.items)
+			switch {
+			case final:
+				p.reason = FireByClose
+			case window.fired:
+				p.reason = FireByLateItem
+			default:
+				p.reason = FireByWatermark
+			}
+			closed = append(closed, &p)
+			window.fired, window.updated = true, false
+		}
+		if final || !a.watermark.Before(end.Add(a.lateness)) {
+			delete(a.windows, start)
+		}
+	}
+	slices.SortFunc(closed, func(p1, p2 *pane[IN]) int {
+		return p1.start.Compare(p2.start)
+	})
+	return closed
+}
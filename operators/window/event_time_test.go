@@ -0,0 +1,121 @@
+package window
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+func TestWindowByEventTime(t *testing.T) {
+	type event struct {
+		name string
+		at   time.Duration // event time, from base
+	}
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	timestamp := func(e event) time.Time { return base.Add(e.at) }
+
+	run := func(t *testing.T, o *WindowOperator[event], events []event) (windows, late []string) {
+		lateOutput := o.GetSideOutput(LateOutput)
+		testutil.RunOperatorTest(t, testutil.OperatorTest{
+			Operator: o,
+			Send:     testutil.SendItems(events...),
+			Tester: func(t *testing.T, out <-chan any) {
+				for data := range out {
+					var names []string
+					for _, e := range data.([]event) {
+						names = append(names, e.name)
+					}
+					windows = append(windows, fmt.Sprint(names))
+				}
+				for data := range lateOutput {
+					late = append(late, data.(event).name)
+				}
+			},
+		})
+		return windows, late
+	}
+
+	events := []event{
+		{"A", 1 * time.Minute},
+		{"B", 12 * time.Minute}, // watermark passes 12:10
+		{"C", 8 * time.Minute},  // out of order
+		{"D", 25 * time.Minute}, // watermark passes 12:20
+		{"E", 5 * time.Minute},  // out of order
+		{"F", 21 * time.Minute},
+	}
+
+	t.Run("out of order items", func(t *testing.T) {
+		o := ByEventTime(10*time.Minute, timestamp)
+		o.SetMaxOutOfOrderness(5 * time.Minute)
+		windows, late := run(t, o, events)
+		if fmt.Sprint(windows) != "[[A C] [B] [D F]]" {
+			t.Fatal("unexpected windows:", windows)
+		}
+		if fmt.Sprint(late) != "[E]" {
+			t.Fatal("unexpected late items:", late)
+		}
+	})
+
+	t.Run("allowed lateness", func(t *testing.T) {
+		o := ByEventTime(10*time.Minute, timestamp)
+		o.SetMaxOutOfOrderness(2 * time.Minute)
+		o.SetAllowedLateness(5 * time.Minute)
+		windows, late := run(t, o, events)
+		if fmt.Sprint(windows) != "[[A] [A C] [B] [D F]]" {
+			t.Fatal("unexpected windows:", windows)
+		}
+		if fmt.Sprint(late) != "[E]" {
+			t.Fatal("unexpected late items:", late)
+		}
+	})
+
+	t.Run("sliding windows", func(t *testing.T) {
+		o := BySlidingEventTime(10*time.Minute, 5*time.Minute, timestamp)
+		o.SetMaxOutOfOrderness(10 * time.Minute)
+		windows, late := run(t, o, events)
+		expected := "[[A] [A C] [B C] [B] [F] [D F] [D]]"
+		if fmt.Sprint(windows) != expected {
+			t.Fatal("unexpected windows:", windows)
+		}
+		if fmt.Sprint(late) != "[E]" {
+			t.Fatal("unexpected late items:", late)
+		}
+	})
+
+	t.Run("hopping windows", func(t *testing.T) {
+		o := BySlidingEventTime(5*time.Minute, 10*time.Minute, timestamp)
+		o.SetMaxOutOfOrderness(10 * time.Minute)
+		var gaps []string
+		o.SetDeadLetterFunc(func(_ context.Context, dl api.DeadLetter) {
+			gaps = append(gaps, dl.Item.(event).name)
+		})
+		windows, late := run(t, o, events)
+		if fmt.Sprint(windows) != "[[A] [B] [F]]" {
+			t.Fatal("unexpected windows:", windows)
+		}
+		if fmt.Sprint(gaps) != "[C D E]" || len(late) != 0 {
+			t.Fatal("unexpected items between windows:", gaps, late)
+		}
+	})
+}
+
+func TestWindowByEventTime_Metadata(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	o := ByEventTime(10*time.Minute, func(at time.Duration) time.Time { return base.Add(at) })
+	o.SetAllowedLateness(10 * time.Minute)
+	o.SetEmitMetadata(true)
+	var windows []string
+	for _, data := range outputs(t, o, testutil.SendItems(1*time.Minute, 12*time.Minute, 3*time.Minute)) {
+		w := data.(Window[time.Duration])
+		windows = append(windows, fmt.Sprintf("%s-%s:%d:%s",
+			w.Start.Format("15:04"), w.End.Format("15:04"), w.Count, w.Reason))
+	}
+	expected := "[12:00-12:10:1:watermark 12:00-12:10:2:late-item 12:10-12:20:1:close]"
+	if fmt.Sprint(windows) != expected {
+		t.Fatal("unexpected windows:", windows)
+	}
+}
@@ -2,6 +2,7 @@ package window
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -9,24 +10,116 @@ import (
 	"github.com/vladimirvivien/automi/api"
 	autoctx "github.com/vladimirvivien/automi/api/context"
 	"github.com/vladimirvivien/automi/log"
+	"github.com/vladimirvivien/automi/operators/internal/node"
 )
 
 // WindowOperator is an operator node that batches incoming streamed items based
 // on provided criteria.
 type WindowOperator[IN any] struct {
-	trigger TriggerFunction[IN]
-	input   <-chan any
-	output  chan any
-	logf    api.StreamLogFunc
+	assigner   func(start time.Time) windowAssigner[IN]
+	agg        *aggregation[IN]
+	bounds     bounds[IN]
+	evictors   []EvictFunction[IN]
+	format     func(p *pane[IN], value any) any
+	keyed      bool  // windows are partitioned by key
+	err        error // configuration error returned by Exec
+	interval   time.Duration
+	started    time.Time // time the operator started, ticks are aligned on it
+	emitEmpty  bool
+	emitMeta   bool
+	outOfOrder time.Duration
+	lateness   time.Duration
+	clock      api.Clock
+	input      <-chan any
+	output     chan any
+	node       node.Node
 }
 
 // New returns a new *WindowOperator
 func New[IN any](trigger TriggerFunction[IN]) *WindowOperator[IN] {
-	return &WindowOperator[IN]{
-		trigger: trigger,
-		output:  make(chan interface{}, 1024),
-		logf:    log.NoLogFunc,
+	op := &WindowOperator[IN]{
+		output: make(chan interface{}, 1024),
+		clock:  api.SystemClock(),
+		node:   node.New("Window"),
 	}
+	op.assigner = func(start time.Time) windowAssigner[IN] {
+		return newTriggerAssigner(trigger, op.agg, op.itemBounds(), start)
+	}
+	return op
+}
+
+// SetClock sets the clock used to timestamp windows and to close
+// time-based windows. It defaults to the system clock.
+func (op *WindowOperator[IN]) SetClock(clock api.Clock) {
+	if clock == nil {
+		clock = api.SystemClock()
+	}
+	op.clock = clock
+}
+
+// SetEmitEmpty specifies whether time-based windows that close without
+// any item are emitted downstream as empty windows. The default is false.
+func (op *WindowOperator[IN]) SetEmitEmpty(emit bool) {
+	op.emitEmpty = emit
+}
+
+// SetEmitMetadata specifies whether windows are emitted as Window[IN] values,
+// which carry the window items along with metadata such as the window time
+// range and the reason it fired, instead of []IN values (or tuple.Pair[K, []IN]
+// values for keyed windows). The default is false.
+func (op *WindowOperator[IN]) SetEmitMetadata(emit bool) {
+	op.emitMeta = emit
+}
+
+// SetMaxItems sets the maximum number of items held by each window. Items that
+// do not fit in a full window are handled according to the overflow policy,
+// see SetOverflowPolicy. The default, zero, means windows are not bounded.
+// Aggregated windows, which hold no item, are not bounded.
+func (op *WindowOperator[IN]) SetMaxItems(max int) {
+	op.bounds.maxItems = max
+}
+
+// SetMaxBytes sets the maximum size, in bytes, of the items held by each
+// window, using the size function to get the size of each item. Items that do
+// not fit in a full window are handled according to the overflow policy, see
+// SetOverflowPolicy. The default, zero, means windows are not bounded.
+// Aggregated windows, which hold no item, are not bounded.
+func (op *WindowOperator[IN]) SetMaxBytes(max int, size func(IN) int) {
+	op.bounds.maxBytes = max
+	op.bounds.size = size
+}
+
+// SetOverflowPolicy sets how items that do not fit in a full window are handled.
+// With the default, OverflowFireEarly, the window is emitted before its trigger
+// fires and the item is added to a new window. Event-time windows are emitted
+// and stay open while sliding windows, which overlap, drop their oldest items
+// instead. Items dropped with OverflowDropOldest or OverflowDropNewest are sent
+// to the DroppedOutput side output, once for every window they are dropped from.
+func (op *WindowOperator[IN]) SetOverflowPolicy(policy OverflowPolicy) {
+	op.bounds.overflow = policy
+}
+
+// SetEvictors sets functions that remove items from a window before the window
+// is emitted. Items are removed when any of the evictors returns true. Evictors
+// are not applied to aggregated windows, which hold no item.
+func (op *WindowOperator[IN]) SetEvictors(evictors ...EvictFunction[IN]) {
+	op.evictors = evictors
+}
+
+// SetMaxOutOfOrderness sets how late, compared to the largest timestamp seen,
+// items of event-time windows may arrive. The watermark of event-time windows
+// trails the largest timestamp by this duration. The default is zero.
+func (op *WindowOperator[IN]) SetMaxOutOfOrderness(d time.Duration) {
+	op.outOfOrder = max(d, 0)
+}
+
+// SetAllowedLateness sets how long, past the watermark, event-time windows are
+// kept after they are emitted. A late item added to a kept window causes the
+// window to be emitted again with all of its items. Items that arrive after
+// all of their windows are discarded are sent to the LateOutput side output.
+// The default is zero.
+func (op *WindowOperator[IN]) SetAllowedLateness(d time.Duration) {
+	op.lateness = max(d, 0)
 }
 
 // SetInput sets the input channel for the operator node
@@ -39,53 +132,87 @@ func (op *WindowOperator[IN]) GetOutput() <-chan any {
 	return op.output
 }
 
+// GetSideOutput returns the side output channel with the specified name,
+// creating it if it does not exist. Items are sent to the api.ErrorOutput
+// side output when the error policy is api.ErrorPolicyReroute, late items
+// of event-time windows are sent to the LateOutput side output, and items
+// dropped from full windows are sent to the DroppedOutput side output.
+// Side outputs must be retrieved before Exec is called.
+func (op *WindowOperator[IN]) GetSideOutput(name string) <-chan any {
+	return op.node.GetSideOutput(name)
+}
+
 // SetLogFunc sets a function called to capture and log stream events
-func (o *WindowOperator[IN]) SetLogFunc(f api.StreamLogFunc) {
-	o.logf = f
+func (op *WindowOperator[IN]) SetLogFunc(f api.StreamLogFunc) {
+	op.node.SetLogFunc(f)
+}
+
+// SetErrFunc sets a function called to report runtime errors to the stream
+func (op *WindowOperator[IN]) SetErrFunc(f api.StreamErrFunc) {
+	op.node.SetErrFunc(f)
+}
+
+// SetDeadLetterFunc sets a function called to send items to the stream
+// dead-letter output when the error policy is api.ErrorPolicyDeadLetter
+func (op *WindowOperator[IN]) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	op.node.SetDeadLetterFunc(f)
+}
+
+// SetErrorPolicy sets how panics recovered from the trigger, key or aggregator
+// functions are handled. The default, api.ErrorPolicySkip, logs the error and
+// drops the item that was being admitted to the window, or the window whose
+// result could not be computed.
+func (op *WindowOperator[IN]) SetErrorPolicy(policy api.ErrorPolicy) {
+	op.node.ErrPolicy = policy
 }
 
 // Exec is the exstarting point of the operator node.
 func (op *WindowOperator[IN]) Exec(ctx context.Context) (err error) {
-	op.logf(ctx, log.LogDebug(
+	op.node.Logf(ctx, log.LogDebug(
 		"Component starting",
 		slog.String("operator", "Window"),
 	))
 
+	if op.err != nil {
+		return op.err
+	}
+
 	if op.input == nil {
 		err = api.ErrInputChannelUndefined
 		return
 	}
 
+	// time-based windows are closed on each tick
+	op.started = op.clock.Now()
+	var ticker api.Ticker
+	if op.interval > 0 {
+		ticker = op.clock.NewTicker(op.interval)
+	}
+
 	go func() {
-		var itemWindow []IN
-		logCtx := autoctx.WithLogF(ctx, op.logf)
+		logCtx := autoctx.WithLogF(ctx, op.node.Logf)
 		exeCtx, cancel := context.WithCancel(logCtx)
-		operatorStartTime := time.Now()
+		assigner := op.assigner(op.started)
 		operatorItemCount := uint64(0)
 
 		defer func() {
-			op.logf(ctx, log.LogDebug(
+			op.node.Logf(ctx, log.LogDebug(
 				"Component closing",
 				slog.String("operator", "Window"),
 			))
 
-			if len(itemWindow) > 0 {
-				select {
-				case op.output <- itemWindow:
-				case <-exeCtx.Done():
-				}
-			}
+			op.emit(exeCtx, assigner.flush(op.clock.Now()))
 
 			cancel()
 			close(op.output)
+			op.node.CloseSideOutputs()
 		}()
 
-		// default to TriggerAll.
-		if op.trigger == nil {
-			op.trigger = TriggerAllFunc[IN]()
+		var tick <-chan time.Time
+		if ticker != nil {
+			defer ticker.Stop()
+			tick = ticker.C()
 		}
-		windowStartTime := time.Now()
-		windowItemCount := uint64(1)
 
 		for {
 			select {
@@ -98,41 +225,49 @@ func (op *WindowOperator[IN]) Exec(ctx context.Context) (err error) {
 
 				itemVal, ok := item.(IN)
 				if !ok {
-					op.logf(ctx, log.LogDebug(
+					op.node.Logf(ctx, log.LogDebug(
 						"Error: unexpected data type",
 						slog.String("operator", "Window"),
 						slog.String("type", fmt.Sprintf("%T", item)),
 					))
+					op.node.DeadLetter(exeCtx, api.UnexpectedType[IN]("Window", item))
 					continue
 				}
 
-				itemWindow = append(itemWindow, itemVal)
-
-				// apply batch trigger function
-				done := false
-				if op.trigger != nil {
-					done = op.trigger(ctx, WindowContext[IN]{
-						OperatorStartTime: operatorStartTime,
-						OperatorItemCount: operatorItemCount,
-						WindowStartTime:   windowStartTime,
-						WindowItemCount:   windowItemCount,
-						Item:              itemVal,
-						ItemWindowTime:    time.Now(),
-					})
+				// assign item to window(s)
+				closed, err := assigner.add(exeCtx, admission[IN]{
+					item:     itemVal,
+					time:     op.clock.Now(),
+					received: operatorItemCount,
+				})
+
+				// output closed windows downstream
+				if !op.emit(exeCtx, closed) {
+					return
 				}
-				if !done {
-					windowItemCount++
-					continue
+				op.drop(exeCtx)
+
+				if errors.Is(err, errLateItem) {
+					op.node.Logf(ctx, log.LogDebug(
+						"Late item: sent to side output",
+						slog.String("operator", "Window"),
+					))
+					op.node.Reroute(exeCtx, LateOutput, itemVal)
+					err = nil
+				}
+				if errors.Is(err, errGapItem) {
+					op.node.DeadLetter(exeCtx, api.DeadLetter{Node: "Window", Reason: "item between windows", Item: itemVal})
+					err = nil
+				}
+				if err != nil {
+					if err := op.node.HandleErr(exeCtx, itemVal, "window function error", err); err != nil {
+						op.node.ReportErr(ctx, err)
+						return
+					}
 				}
 
-				// done batching, output downstream
-				select {
-				case op.output <- itemWindow:
-					// reset window
-					windowStartTime = time.Now()
-					windowItemCount = 1
-					itemWindow = make([]IN, 0)
-				case <-exeCtx.Done():
+			case now := <-tick:
+				if !op.emit(exeCtx, assigner.tick(now)) {
 					return
 				}
 
@@ -143,3 +278,114 @@ func (op *WindowOperator[IN]) Exec(ctx context.Context) (err error) {
 	}()
 	return nil
 }
+
+// emit sends the items of closed panes downstream, once evicted, skipping
+// empty panes unless configured otherwise. It returns false if ctx is done or
+// if an evictor or the result of an aggregated window fails and the operator
+// must stop.
+func (op *WindowOperator[IN]) emit(ctx context.Context, panes []*pane[IN]) bool {
+	for _, p := range panes {
+		if err := op.evict(ctx, p); err != nil {
+			op.node.ReportErr(ctx, err)
+			return false
+		}
+		if p.count == 0 && !op.emitEmpty {
+			continue
+		}
+		window, err := op.window(p)
+		if err != nil {
+			if err := op.node.HandleErr(ctx, nil, "window function error", err); err != nil {
+				op.node.ReportErr(ctx, err)
+				return false
+			}
+			continue
+		}
+		select {
+		case op.output <- window:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// itemBounds returns the bounds of panes holding items, or nil
+// if windows are not bounded or are aggregated
+func (op *WindowOperator[IN]) itemBounds() *bounds[IN] {
+	if op.agg != nil || !op.bounds.enabled() {
+		return nil
+	}
+	return &op.bounds
+}
+
+// drop sends the items dropped from full windows to the DroppedOutput side output
+func (op *WindowOperator[IN]) drop(ctx context.Context) {
+	for _, item := range op.bounds.drain() {
+		op.node.Reroute(ctx, DroppedOutput, item)
+	}
+}
+
+// evict removes the items of pane p matching an evictor. Items for which
+// an evictor fails are removed as well. It returns an error if the operator
+// must stop.
+func (op *WindowOperator[IN]) evict(ctx context.Context, p *pane[IN]) error {
+	if len(op.evictors) == 0 || op.agg != nil {
+		return nil
+	}
+	kept := p.items[:0]
+	for _, item := range p.items {
+		evicted, err := apply(func(item IN) bool {
+			for _, evictor := range op.evictors {
+				if evictor(ctx, item) {
+					return true
+				}
+			}
+			return false
+		}, item)
+		if err != nil {
+			if err := op.node.HandleErr(ctx, item, "window function error", err); err != nil {
+				return err
+			}
+			continue
+		}
+		if !evicted {
+			kept = append(kept, item)
+		}
+	}
+	p.items = kept
+	p.count = len(kept)
+	return nil
+}
+
+// window returns the value emitted downstream for pane p
+func (op *WindowOperator[IN]) window(p *pane[IN]) (any, error) {
+	var value, result any
+	if op.agg != nil {
+		var err error
+		if result, err = op.agg.value(p); err != nil {
+			return nil, err
+		}
+		value = result
+	} else {
+		if p.items == nil {
+			p.items = make([]IN, 0)
+		}
+		value = p.items
+	}
+
+	switch {
+	case op.emitMeta:
+		return Window[IN]{
+			Items:  p.items,
+			Result: result,
+			Start:  p.start,
+			End:    p.end,
+			Count:  p.count,
+			Reason: p.reason,
+			Key:    p.key,
+		}, nil
+	case op.format != nil:
+		return op.format(p, value), nil
+	}
+	return value, nil
+}
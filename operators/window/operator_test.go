@@ -2,11 +2,14 @@ package window
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/vladimirvivien/automi/api"
 	"github.com/vladimirvivien/automi/testutil"
 )
 
@@ -310,3 +313,56 @@ func BenchmarkWindowExec(b *testing.B) {
 		b.Fatal("Took too long")
 	}
 }
+
+func TestWindowExec_TriggerPanic(t *testing.T) {
+	items := testutil.SendItems(1, 2, 3, 4, 5, 6)
+
+	// panics on item 3, windows close on every other admitted item
+	trigger := func(ctx context.Context, wctx WindowContext[int]) bool {
+		if wctx.Item == 3 {
+			panic("bad item")
+		}
+		return wctx.WindowItemCount >= 2
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		batches := outputs(t, ByFunc(trigger), items)
+		if fmt.Sprint(batches) != "[[1 2] [4 5] [6]]" {
+			t.Fatal("unexpected batches:", batches)
+		}
+	})
+
+	t.Run("dead letter", func(t *testing.T) {
+		o := ByFunc(trigger)
+		o.SetErrorPolicy(api.ErrorPolicyDeadLetter)
+		var letters []api.DeadLetter
+		o.SetDeadLetterFunc(func(ctx context.Context, dl api.DeadLetter) {
+			letters = append(letters, dl)
+		})
+		batches := outputs(t, o, items)
+		if fmt.Sprint(batches) != "[[1 2] [4 5] [6]]" {
+			t.Fatal("unexpected batches:", batches)
+		}
+		if len(letters) != 1 || letters[0].Item != 3 {
+			t.Fatalf("unexpected dead letters: %#v", letters)
+		}
+		var panicErr *api.PanicError
+		if !errors.As(letters[0].Err, &panicErr) || len(panicErr.Stack) == 0 {
+			t.Fatalf("expecting *api.PanicError with stack, got %v", letters[0].Err)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		o := ByFunc(trigger)
+		o.SetErrorPolicy(api.ErrorPolicyFail)
+		var reported error
+		o.SetErrFunc(func(ctx context.Context, err error) { reported = err })
+		batches := outputs(t, o, items)
+		if fmt.Sprint(batches) != "[[1 2]]" {
+			t.Fatal("unexpected batches:", batches)
+		}
+		if reported == nil {
+			t.Fatal("expecting reported error")
+		}
+	})
+}
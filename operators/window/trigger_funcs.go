@@ -2,6 +2,7 @@ package window
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -23,11 +24,16 @@ func TriggerBySizeFunc[T any](size uint64) TriggerFunction[T] {
 }
 
 // TriggerByDurationFunc is a function that can trigger a window based
-// on specified duration of the current window runtime.
+// on specified duration of the current window runtime. The runtime is
+// measured from the window start to the admission of the item, both read
+// from the operator clock, which makes the trigger usable with SetClock.
 func TriggerByDurationFunc[T any](duration time.Duration) TriggerFunction[T] {
 	return func(ctx context.Context, wctx WindowContext[T]) bool {
-		elapsed := time.Since(wctx.WindowStartTime)
-		return elapsed >= duration
+		now := wctx.ItemWindowTime
+		if now.IsZero() {
+			now = time.Now()
+		}
+		return now.Sub(wctx.WindowStartTime) >= duration
 	}
 }
 
@@ -36,3 +42,69 @@ func TriggerByDurationFunc[T any](duration time.Duration) TriggerFunction[T] {
 func TriggerByFunc[T any](trigger TriggerFunction[T]) TriggerFunction[T] {
 	return trigger
 }
+
+// TriggerByItemFunc is a function that triggers the window when the predicate
+// returns true for the item admitted to the window, such as a delimiter item.
+// The item is included in the closing window.
+func TriggerByItemFunc[T any](predicate func(T) bool) TriggerFunction[T] {
+	return func(ctx context.Context, wctx WindowContext[T]) bool {
+		return predicate(wctx.Item)
+	}
+}
+
+// TriggerByByteSizeFunc is a function that triggers the window once the
+// accumulated size of its items, as returned by the size function, reaches
+// the specified limit. The trigger keeps the accumulated size of the current
+// window, per key for keyed windows, until the next window starts. This keeps
+// it triggering when combined with AllOf or Not. It must not be shared across
+// operators.
+func TriggerByByteSizeFunc[T any](limit uint64, size func(T) int) TriggerFunction[T] {
+	var mutex sync.Mutex
+	accumulated := make(map[any]uint64)
+	return func(ctx context.Context, wctx WindowContext[T]) bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		total := uint64(max(size(wctx.Item), 0))
+		if wctx.WindowItemCount > 1 { // not a new window
+			total += accumulated[wctx.Key]
+		}
+		accumulated[wctx.Key] = total
+		return total >= limit
+	}
+}
+
+// AnyOf is a function that triggers the window when any of the specified
+// triggers returns true. All triggers are evaluated for every item, to keep
+// stateful triggers up to date, for instance:
+//
+//	window.AnyOf(window.TriggerBySizeFunc[T](100), window.TriggerByDurationFunc[T](5*time.Second))
+func AnyOf[T any](triggers ...TriggerFunction[T]) TriggerFunction[T] {
+	return func(ctx context.Context, wctx WindowContext[T]) bool {
+		done := false
+		for _, trigger := range triggers {
+			done = trigger(ctx, wctx) || done
+		}
+		return done
+	}
+}
+
+// AllOf is a function that triggers the window when all of the specified
+// triggers return true. All triggers are evaluated for every item.
+func AllOf[T any](triggers ...TriggerFunction[T]) TriggerFunction[T] {
+	return func(ctx context.Context, wctx WindowContext[T]) bool {
+		done := len(triggers) > 0
+		for _, trigger := range triggers {
+			done = trigger(ctx, wctx) && done
+		}
+		return done
+	}
+}
+
+// Not is a function that triggers the window when the
+// specified trigger returns false.
+func Not[T any](trigger TriggerFunction[T]) TriggerFunction[T] {
+	return func(ctx context.Context, wctx WindowContext[T]) bool {
+		return !trigger(ctx, wctx)
+	}
+}
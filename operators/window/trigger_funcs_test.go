@@ -2,8 +2,11 @@ package window
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
+
+	"github.com/vladimirvivien/automi/testutil"
 )
 
 func TestWindowTriggerAllFunc(t *testing.T) {
@@ -136,3 +139,142 @@ func TestWindowTriggeByFunc(t *testing.T) {
 		}
 	}
 }
+
+func TestWindowTriggerByItemFunc(t *testing.T) {
+	trigger := TriggerByItemFunc(func(item string) bool { return item == "" })
+	if trigger(context.Background(), WindowContext[string]{Item: "hello"}) {
+		t.Fatal("window.TriggerByItemFunc was triggered inappropriately")
+	}
+	if !trigger(context.Background(), WindowContext[string]{Item: ""}) {
+		t.Fatal("window.TriggerByItemFunc should have been triggered by delimiter")
+	}
+}
+
+func TestWindowTriggerByByteSizeFunc(t *testing.T) {
+	trigger := TriggerByByteSizeFunc(10, func(item string) int { return len(item) })
+
+	// windows per key are accounted separately
+	tests := []struct {
+		item  string
+		key   any
+		count uint64
+		done  bool
+	}{
+		{item: "hello", key: "a", count: 1, done: false},
+		{item: "hello", key: "b", count: 1, done: false},
+		{item: "hi", key: "a", count: 2, done: false},
+		{item: "there", key: "a", count: 3, done: true},
+		{item: "hi", key: "a", count: 1, done: false}, // new window
+		{item: "world", key: "b", count: 2, done: true},
+		{item: "bye", key: "a", count: 2, done: false},
+	}
+
+	for _, test := range tests {
+		wctx := WindowContext[string]{Item: test.item, Key: test.key, WindowItemCount: test.count}
+		if trigger(context.Background(), wctx) != test.done {
+			t.Fatalf("window.TriggerByByteSizeFunc was triggered inappropriately for %s:%s", test.key, test.item)
+		}
+	}
+}
+
+func TestWindowTriggerByByteSizeFunc_Combinators(t *testing.T) {
+	byteSize := func() TriggerFunction[string] {
+		return TriggerByByteSizeFunc(4, func(item string) int { return len(item) })
+	}
+	delimiter := TriggerByItemFunc(func(item string) bool { return item == "|" })
+
+	tests := []struct {
+		name     string
+		trigger  TriggerFunction[string]
+		items    []string
+		expected string
+	}{
+		{
+			name:     "all of",
+			trigger:  AllOf(byteSize(), delimiter),
+			items:    []string{"a", "b", "|", "c", "|", "de", "|", "fg", "|"},
+			expected: "[[a b | c |] [de | fg |]]",
+		},
+		{
+			name:     "not",
+			trigger:  Not(byteSize()),
+			items:    []string{"x", "abcd", "e", "f"},
+			expected: "[[x] [abcd e f]]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			windows := outputs(t, ByFunc(test.trigger), testutil.SendItems(test.items...))
+			if fmt.Sprint(windows) != test.expected {
+				t.Fatal("unexpected windows:", windows)
+			}
+		})
+	}
+}
+
+func TestWindowTriggerCombinators(t *testing.T) {
+	never := TriggerAllFunc[int]()
+	always := Not(never)
+	var calls int
+	counting := func(done bool) TriggerFunction[int] {
+		return func(ctx context.Context, wctx WindowContext[int]) bool {
+			calls++
+			return done
+		}
+	}
+
+	tests := []struct {
+		name    string
+		trigger TriggerFunction[int]
+		done    bool
+		calls   int
+	}{
+		{name: "not", trigger: Not(always), done: false},
+		{name: "any of none", trigger: AnyOf[int](), done: false},
+		{name: "any of, one done", trigger: AnyOf(never, always), done: true},
+		{name: "any of, none done", trigger: AnyOf(never, never), done: false},
+		{name: "all of none", trigger: AllOf[int](), done: false},
+		{name: "all of, all done", trigger: AllOf(always, always), done: true},
+		{name: "all of, one not done", trigger: AllOf(always, never), done: false},
+		{name: "any of evaluates all", trigger: AnyOf(counting(true), counting(true)), done: true, calls: 2},
+		{name: "all of evaluates all", trigger: AllOf(counting(false), counting(true)), done: false, calls: 2},
+		{
+			name:    "size or item",
+			trigger: AnyOf(TriggerBySizeFunc[int](3), TriggerByItemFunc(func(i int) bool { return i < 0 })),
+			done:    true,
+		},
+	}
+
+	for _, test := range tests {
+		calls = 0
+		wctx := WindowContext[int]{Item: -1, WindowItemCount: 1}
+		if test.trigger(context.Background(), wctx) != test.done {
+			t.Fatalf("%s: trigger was triggered inappropriately", test.name)
+		}
+		if calls != test.calls {
+			t.Fatalf("%s: unexpected trigger calls %d", test.name, calls)
+		}
+	}
+}
+
+func TestWindowTriggerCombinators_Clock(t *testing.T) {
+	// close each window at 3 items or once it has been open for 10s
+	clock := testutil.NewFakeClock()
+	o := ByFunc(AnyOf(TriggerBySizeFunc[string](3), TriggerByDurationFunc[string](10*time.Second)))
+	o.SetClock(clock)
+	windows := outputs(t, o, func(in chan<- any) {
+		clock.Send(in, "A")
+		clock.Send(in, "B")
+		clock.Send(in, "C") // closes on size
+		clock.Advance(4 * time.Second)
+		clock.Send(in, "D")
+		clock.Advance(7 * time.Second)
+		clock.Send(in, "E") // closes on duration
+		clock.Advance(time.Second)
+		clock.Send(in, "F")
+	})
+	if fmt.Sprint(windows) != "[[A B C] [D E] [F]]" {
+		t.Fatal("unexpected windows:", windows)
+	}
+}
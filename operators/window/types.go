@@ -12,6 +12,37 @@ type WindowContext[IN any] struct {
 	WindowItemCount   uint64    // The current item count of the window
 	Item              IN        // The last data item admitted to the current window
 	ItemWindowTime    time.Time // The time an item got admitted to the current window
+	Key               any       // The key of the current window, for keyed windows
 }
 
 type TriggerFunction[IN any] func(context.Context, WindowContext[IN]) bool
+
+// EvictFunction returns true for the items to remove from a window before
+// it is emitted, see WindowOperator.SetEvictors
+type EvictFunction[IN any] func(context.Context, IN) bool
+
+// FireReason describes why a window was emitted
+type FireReason string
+
+const (
+	FireByTrigger    FireReason = "trigger"     // The trigger function returned true
+	FireByTimer      FireReason = "timer"       // The window duration elapsed
+	FireByCount      FireReason = "count"       // A count-based sliding window slid
+	FireBySessionGap FireReason = "session-gap" // No item arrived for the session gap
+	FireByWatermark  FireReason = "watermark"   // The watermark passed the end of an event-time window
+	FireByLateItem   FireReason = "late-item"   // A late item was added to an emitted event-time window
+	FireByOverflow   FireReason = "overflow"    // The window was full, see OverflowFireEarly
+	FireByClose      FireReason = "close"       // The operator input was closed
+)
+
+// Window is emitted by window operators, configured with
+// WindowOperator.SetEmitMetadata, for each window.
+type Window[IN any] struct {
+	Items  []IN       // The items of the window, nil for aggregated windows
+	Result any        // The aggregation result, for aggregated windows
+	Start  time.Time  // The time the window started (its lower bound for event-time windows)
+	End    time.Time  // The time the window closed (its upper bound for event-time windows)
+	Count  int        // The number of items in the window
+	Reason FireReason // The reason the window was emitted
+	Key    any        // The key of the window, for keyed windows
+}
@@ -1,6 +1,11 @@
 package window
 
-import "time"
+import (
+	"time"
+
+	"github.com/vladimirvivien/automi/api/tuple"
+	"github.com/vladimirvivien/automi/funcs"
+)
 
 // Batch creaets a window that only closes when the stream is closed
 // thus batching all streamed items. This Generally useful for
@@ -16,12 +21,178 @@ func BySize[IN any](size uint64) *WindowOperator[IN] {
 }
 
 // ByDuration creates a new window to collect items for the specified duration.
+// Windows are closed by a ticker, even when no item arrives, and windows
+// without items are dropped unless WindowOperator.SetEmitEmpty is used.
 func ByDuration[IN any](dur time.Duration) *WindowOperator[IN] {
-	trigger := TriggerByDurationFunc[IN](dur)
-	return New(trigger)
+	op := New(TriggerAllFunc[IN]())
+	op.interval = dur
+	return op
 }
 
 // ByFunc creates a new window using the specified trigger function.
 func ByFunc[IN any](trigger TriggerFunction[IN]) *WindowOperator[IN] {
 	return New(trigger)
 }
+
+// BySlidingDuration creates a new sliding window that, every slide duration,
+// emits the items collected during the last size duration. When slide is
+// shorter than size, windows overlap and an item is emitted with every window
+// it falls into (for instance, the last minute emitted every 10 seconds). When
+// slide is longer than size, windows are hopping and items that arrive between
+// windows are dropped. Only the items of the last size duration are kept.
+func BySlidingDuration[IN any](size, slide time.Duration) *WindowOperator[IN] {
+	if slide <= 0 {
+		slide = size
+	}
+	op := New(TriggerAllFunc[IN]())
+	op.assigner = func(time.Time) windowAssigner[IN] {
+		if op.agg != nil {
+			return newSlicedTimeAssigner(op.agg, op.started, size, slide)
+		}
+		return &slidingTimeAssigner[IN]{size: size, bounds: op.itemBounds()}
+	}
+	op.interval = slide
+	return op
+}
+
+// BySlidingSize creates a new count-based sliding window that, every slide
+// items, emits the last size items received (for instance, the last 100 items
+// emitted every 10). Once a window is emitted, only the items that belong to
+// the next window are kept: none when slide is not smaller than size. With
+// Keyed, the state of a key is therefore released after each window when
+// windows do not overlap, but kept until the input is closed when they do.
+func BySlidingSize[IN any](size, slide uint64) *WindowOperator[IN] {
+	size, slide = max(size, 1), max(slide, 1)
+	op := New(TriggerAllFunc[IN]())
+	op.assigner = func(time.Time) windowAssigner[IN] {
+		if op.agg != nil {
+			return newSlicedCountAssigner(op.agg, int(size), int(slide))
+		}
+		return &slidingCountAssigner[IN]{size: int(size), slide: int(slide), bounds: op.itemBounds()}
+	}
+	return op
+}
+
+// BySession creates a new session window that closes once no item is received
+// for the specified gap duration. Sessions are closed on a timer, checked four
+// times per gap duration, even when no new item arrives.
+func BySession[IN any](gap time.Duration) *WindowOperator[IN] {
+	op := New(TriggerAllFunc[IN]())
+	op.assigner = func(time.Time) windowAssigner[IN] {
+		return &sessionAssigner[IN]{gap: gap, agg: op.agg, bounds: op.itemBounds()}
+	}
+	op.interval = max(gap/4, time.Millisecond)
+	return op
+}
+
+// ByKeyedSession creates a new session window, like BySession, where each key
+// returned by the key function gets its own session. Sessions are emitted as
+// tuple.Pair[K, []IN] values holding the key and the items of the session.
+func ByKeyedSession[IN any, K comparable](gap time.Duration, key func(IN) K) *WindowOperator[IN] {
+	return Keyed(BySession[IN](gap), key)
+}
+
+// Keyed partitions the windows of op by the key returned by the key function,
+// for instance a customer ID. Each key gets its own window state and trigger,
+// created when the first item with that key arrives and released once its
+// window is emitted. Windows are emitted as tuple.Pair[K, []IN] values holding
+// the key and its items:
+//
+//	window.Keyed(window.BySize[Order](100), func(o Order) string { return o.CustomerID })
+//
+// The WindowContext passed to the trigger function of a key reports the time the
+// key state was created as OperatorStartTime. Use KeyedAggregate to aggregate
+// keyed windows: Exec returns an error if op is already aggregated.
+func Keyed[IN any, K comparable](op *WindowOperator[IN], key func(IN) K) *WindowOperator[IN] {
+	if op.agg != nil {
+		op.err = errKeyedAggregate
+	}
+	return keyed(op, key)
+}
+
+// keyed partitions the windows of op by key
+func keyed[IN any, K comparable](op *WindowOperator[IN], key func(IN) K) *WindowOperator[IN] {
+	op.keyed = true
+	assigner := op.assigner
+	op.assigner = func(time.Time) windowAssigner[IN] {
+		return newKeyedAssigner(func(item IN) any { return key(item) }, assigner)
+	}
+	op.format = func(p *pane[IN], _ any) any {
+		return tuple.Pair[K, []IN]{Val1: p.key.(K), Val2: p.items}
+	}
+	return op
+}
+
+// Aggregate configures the windows of op to be aggregated incrementally with
+// the specified aggregator: each window keeps a single accumulator, into which
+// items are added as they arrive, rather than all of its items. Windows are
+// emitted as the OUT results of the aggregator:
+//
+//	window.Aggregate(window.ByDuration[Event](time.Hour), funcs.CountAggregator[Event]())
+//
+// Sliding windows keep an accumulator for each slice of the window (a slice
+// being the greatest common divisor of size and slide) and merge the slices
+// of a window when it is emitted, which requires the aggregator to have a
+// Merge function. Use KeyedAggregate to aggregate keyed windows: Exec returns
+// an error if op is already keyed.
+func Aggregate[IN, ACC, OUT any](op *WindowOperator[IN], agg funcs.Aggregator[IN, ACC, OUT]) *WindowOperator[IN] {
+	if op.keyed {
+		op.err = errKeyedAggregate
+	}
+	return aggregate(op, agg)
+}
+
+// aggregate configures the windows of op to be aggregated with agg
+func aggregate[IN, ACC, OUT any](op *WindowOperator[IN], agg funcs.Aggregator[IN, ACC, OUT]) *WindowOperator[IN] {
+	op.agg = newAggregation(agg)
+	op.format = nil
+	return op
+}
+
+// KeyedAggregate partitions the windows of op by key, like Keyed, and
+// aggregates each window, like Aggregate. Windows are emitted as
+// tuple.Pair[K, OUT] values holding the key and the aggregation result.
+func KeyedAggregate[IN any, K comparable, ACC, OUT any](op *WindowOperator[IN], key func(IN) K, agg funcs.Aggregator[IN, ACC, OUT]) *WindowOperator[IN] {
+	op = aggregate(keyed(op, key), agg)
+	op.format = func(p *pane[IN], value any) any {
+		result, _ := value.(OUT)
+		return tuple.Pair[K, OUT]{Val1: p.key.(K), Val2: result}
+	}
+	return op
+}
+
+// ByEventTime creates a new event-time window that batches items by the time
+// returned by the timestamp function rather than by the time items arrive.
+// Windows last the specified size and start at multiples of size (for instance
+// on the hour for one-hour windows). A window is emitted once the watermark,
+// derived from item timestamps, passes its end. Use SetMaxOutOfOrderness and
+// SetAllowedLateness to handle items that arrive out of order.
+func ByEventTime[IN any](size time.Duration, timestamp func(IN) time.Time) *WindowOperator[IN] {
+	return BySlidingEventTime(size, size, timestamp)
+}
+
+// BySlidingEventTime creates a new event-time window, like ByEventTime, where
+// windows start every slide duration. When slide is shorter than size, windows
+// overlap and an item is emitted with every window its timestamp falls into.
+// When slide is longer than size, windows leave gaps: items whose timestamp
+// falls between two windows are sent to the stream dead-letter output.
+func BySlidingEventTime[IN any](size, slide time.Duration, timestamp func(IN) time.Time) *WindowOperator[IN] {
+	size = max(size, time.Nanosecond)
+	if slide <= 0 {
+		slide = size
+	}
+	op := New(TriggerAllFunc[IN]())
+	op.assigner = func(time.Time) windowAssigner[IN] {
+		return &eventTimeAssigner[IN]{
+			size:       size,
+			slide:      slide,
+			outOfOrder: op.outOfOrder,
+			lateness:   op.lateness,
+			timestamp:  timestamp,
+			agg:        op.agg,
+			bounds:     op.itemBounds(),
+			windows:    make(map[int64]*eventWindow[IN]),
+		}
+	}
+	return op
+}
@@ -0,0 +1,272 @@
+package window
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/api/tuple"
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+// outputs runs o, fed by send, and returns its output
+func outputs(t *testing.T, o api.Operator, send func(chan<- any)) []any {
+	t.Helper()
+	var out []any
+	testutil.RunOperatorTest(t, testutil.OperatorTest{
+		Operator: o,
+		Send:     send,
+		Tester: func(t *testing.T, output <-chan any) {
+			for data := range output {
+				out = append(out, data)
+			}
+		},
+	})
+	return out
+}
+
+func TestWindowBySlidingDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     time.Duration
+		slide    time.Duration
+		expected string
+	}{
+		{name: "overlapping windows", size: 10 * time.Second, slide: 5 * time.Second, expected: "[[A B] [B C] [C D] [D]]"},
+		{name: "hopping windows", size: 4 * time.Second, slide: 5 * time.Second, expected: "[[B] [C] [D]]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := testutil.NewFakeClock()
+			o := BySlidingDuration[string](test.size, test.slide)
+			o.SetClock(clock)
+
+			windows := outputs(t, o, func(in chan<- any) {
+				// windows cover (t-size, t] at every slide
+				clock.Send(in, "A") // t=0
+				clock.Advance(3 * time.Second)
+				clock.Send(in, "B")            // t=3
+				clock.Advance(4 * time.Second) // t=5 emits
+				clock.Send(in, "C")            // t=7
+				clock.Advance(5 * time.Second) // t=10 emits
+				clock.Send(in, "D")            // t=12
+				clock.Advance(3 * time.Second) // t=15 emits
+				clock.Advance(5 * time.Second) // t=20 emits window with D (size 10s)
+			})
+			if fmt.Sprint(windows) != test.expected {
+				t.Fatal("unexpected windows:", windows)
+			}
+		})
+	}
+}
+
+func TestWindowBySlidingSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     uint64
+		slide    uint64
+		expected string
+	}{
+		{name: "overlapping windows", size: 4, slide: 2, expected: "[[1 2] [1 2 3 4] [3 4 5 6] [5 6 7]]"},
+		{name: "tumbling windows", size: 3, slide: 3, expected: "[[1 2 3] [4 5 6] [7]]"},
+		{name: "hopping windows", size: 2, slide: 3, expected: "[[2 3] [5 6]]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := BySlidingSize[int](test.size, test.slide)
+			windows := outputs(t, o, testutil.SendItems(1, 2, 3, 4, 5, 6, 7))
+			if fmt.Sprint(windows) != test.expected {
+				t.Fatal("unexpected windows:", windows)
+			}
+		})
+	}
+}
+
+func TestWindowBySession(t *testing.T) {
+	clock := testutil.NewFakeClock()
+	o := BySession[string](10 * time.Second)
+	o.SetClock(clock)
+
+	windows := outputs(t, o, func(in chan<- any) {
+		clock.Send(in, "A")
+		clock.Advance(5 * time.Second)
+		clock.Send(in, "B")
+		clock.Advance(15 * time.Second) // gap elapsed, closed on timer
+		clock.Send(in, "C")
+		clock.Advance(9 * time.Second)
+		clock.Send(in, "D")
+	})
+	if fmt.Sprint(windows) != "[[A B] [C D]]" {
+		t.Fatal("unexpected windows:", windows)
+	}
+}
+
+func TestWindowByKeyedSession(t *testing.T) {
+	type click struct {
+		user string
+		page string
+	}
+
+	clock := testutil.NewFakeClock()
+	o := ByKeyedSession(10*time.Second, func(c click) string { return c.user })
+	o.SetClock(clock)
+
+	var sessions []string
+	send := func(in chan<- any) {
+		clock.Send(in, click{"alice", "home"})
+		clock.Advance(5 * time.Second)
+		clock.Send(in, click{"bob", "home"})
+		clock.Send(in, click{"alice", "cart"})
+		clock.Advance(12 * time.Second) // both sessions closed on timer
+		clock.Send(in, click{"bob", "search"})
+		clock.Advance(5 * time.Second)
+		clock.Send(in, click{"alice", "checkout"})
+	}
+	for _, data := range outputs(t, o, send) {
+		session := data.(tuple.Pair[string, []click])
+		var pages []string
+		for _, c := range session.Val2 {
+			pages = append(pages, c.page)
+		}
+		sessions = append(sessions, fmt.Sprintf("%s:%v", session.Val1, pages))
+	}
+	expected := "[alice:[home cart] bob:[home] bob:[search] alice:[checkout]]"
+	if fmt.Sprint(sessions) != expected {
+		t.Fatal("unexpected sessions:", sessions)
+	}
+}
+
+func TestWindowKeyed(t *testing.T) {
+	type order struct {
+		customer string
+		amount   int
+	}
+	customer := func(o order) string { return o.customer }
+
+	byDuration := Keyed(ByDuration[order](10*time.Second), customer)
+	clock := testutil.NewFakeClock()
+	byDuration.SetClock(clock)
+
+	tests := []struct {
+		name     string
+		op       *WindowOperator[order]
+		send     func(in chan<- any)
+		expected string
+	}{
+		{
+			name: "by size",
+			op:   Keyed(BySize[order](2), customer),
+			send: func(in chan<- any) {
+				for i, c := range []string{"a", "b", "a", "c", "b", "b"} {
+					in <- order{customer: c, amount: i}
+				}
+			},
+			expected: "[a:[0 2] b:[1 4] c:[3] b:[5]]",
+		},
+		{
+			// close each window at 3 items or when its amounts reach 10
+			name: "by combined triggers",
+			op: Keyed(ByFunc(AnyOf(
+				TriggerBySizeFunc[order](3),
+				TriggerByByteSizeFunc(10, func(o order) int { return o.amount }),
+			)), customer),
+			send: func(in chan<- any) {
+				for i, c := range []string{"a", "b", "a", "b", "a", "b", "a", "b"} {
+					in <- order{customer: c, amount: i * 2}
+				}
+			},
+			expected: "[a:[0 4 8] b:[2 6 10] a:[12] b:[14]]",
+		},
+		{
+			name: "by duration",
+			op:   byDuration,
+			send: func(in chan<- any) {
+				clock.Send(in, order{"a", 1})
+				clock.Send(in, order{"b", 2})
+				clock.Send(in, order{"a", 3})
+				clock.Advance(10 * time.Second)
+				clock.Send(in, order{"b", 4})
+			},
+			expected: "[a:[1 3] b:[2] b:[4]]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var windows []string
+			for _, data := range outputs(t, test.op, test.send) {
+				window := data.(tuple.Pair[string, []order])
+				var amounts []int
+				for _, ord := range window.Val2 {
+					amounts = append(amounts, ord.amount)
+				}
+				windows = append(windows, fmt.Sprintf("%s:%v", window.Val1, amounts))
+			}
+			if fmt.Sprint(windows) != test.expected {
+				t.Fatal("unexpected windows:", windows)
+			}
+		})
+	}
+}
+
+func TestWindowEmitMetadata(t *testing.T) {
+	clock := testutil.NewFakeClock()
+	start := clock.Now()
+	byDuration := ByDuration[string](5 * time.Second)
+	byDuration.SetClock(clock)
+
+	tests := []struct {
+		name     string
+		op       *WindowOperator[string]
+		send     func(in chan<- any)
+		format   func(w Window[string]) string
+		expected string
+	}{
+		{
+			name: "by duration",
+			op:   byDuration,
+			send: func(in chan<- any) {
+				clock.Send(in, "A")
+				clock.Send(in, "B")
+				clock.Advance(5 * time.Second) // closes [A B]
+				clock.Advance(2 * time.Second)
+				clock.Send(in, "C") // flushed on close
+			},
+			format: func(w Window[string]) string {
+				return fmt.Sprintf("%v:%d:%s:%s-%s", w.Items, w.Count, w.Reason, w.Start.Sub(start), w.End.Sub(start))
+			},
+			expected: "[[A B]:2:timer:0s-5s [C]:1:close:5s-7s]",
+		},
+		{
+			name: "keyed by size",
+			op:   Keyed(BySize[string](2), func(s string) byte { return s[0] }),
+			send: testutil.SendItems("a1", "b1", "a2", "b2", "b3"),
+			format: func(w Window[string]) string {
+				return fmt.Sprintf("%c:%v:%d:%s", w.Key, w.Items, w.Count, w.Reason)
+			},
+			expected: "[a:[a1 a2]:2:trigger b:[b1 b2]:2:trigger b:[b3]:1:close]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.op.SetEmitMetadata(true)
+			testutil.RunOperatorTest(t, testutil.OperatorTest{
+				Operator: test.op,
+				Send:     test.send,
+				Tester: func(t *testing.T, out <-chan any) {
+					var windows []string
+					for data := range out {
+						windows = append(windows, test.format(data.(Window[string])))
+					}
+					if fmt.Sprint(windows) != test.expected {
+						t.Error("unexpected windows:", windows)
+					}
+				},
+			})
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package reflection
 
-import "reflect"
+import (
+	"cmp"
+	"reflect"
+)
 
 // IsIntValue returns true if val is an integer value
 func IsIntValue(val reflect.Value) bool {
@@ -35,12 +38,52 @@ func ValueAsFloat(item reflect.Value) float64 {
 	if IsFloatValue(itemVal) {
 		return itemVal.Float()
 	}
+	if isUintValue(itemVal) {
+		return float64(itemVal.Uint())
+	}
 	if IsIntValue(itemVal) {
 		return float64(itemVal.Int())
 	}
 	return 0.0
 }
 
+// isUintValue returns true if val is an unsigned integer value
+func isUintValue(val reflect.Value) bool {
+	switch val.Type().Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// Compare does a type-based comparison of itemI and itemJ values, returning
+// -1, 0 or +1 as cmp.Compare does. It returns false if the values are not
+// both numbers or both strings.
+func Compare(itemI, itemJ reflect.Value) (int, bool) {
+	if itemI.Type().Kind() == reflect.Interface {
+		itemI = itemI.Elem()
+	}
+	if itemJ.Type().Kind() == reflect.Interface {
+		itemJ = itemJ.Elem()
+	}
+	if !itemI.IsValid() || !itemJ.IsValid() {
+		return 0, false
+	}
+
+	isNumber := func(val reflect.Value) bool { return IsIntValue(val) || IsFloatValue(val) }
+	switch {
+	case isUintValue(itemI) && isUintValue(itemJ):
+		return cmp.Compare(itemI.Uint(), itemJ.Uint()), true
+	case IsIntValue(itemI) && IsIntValue(itemJ) && !isUintValue(itemI) && !isUintValue(itemJ):
+		return cmp.Compare(itemI.Int(), itemJ.Int()), true
+	case isNumber(itemI) && isNumber(itemJ):
+		return cmp.Compare(ValueAsFloat(itemI), ValueAsFloat(itemJ)), true
+	case itemI.Type().Kind() == reflect.String && itemJ.Type().Kind() == reflect.String:
+		return cmp.Compare(itemI.String(), itemJ.String()), true
+	}
+	return 0, false
+}
+
 // IsLess does a type-based comparison of itemI and itemJ values
 func IsLess(itemI, itemJ reflect.Value) bool {
 	switch {
@@ -0,0 +1,188 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/log"
+)
+
+// BackpressurePolicy determines how a BroadcastSink handles
+// downstream sinks that fall behind.
+type BackpressurePolicy uint8
+
+const (
+	BackpressureBlock BackpressurePolicy = iota // Wait for the slowest sink (default)
+	BackpressureDrop                            // Drop items for sinks that are not ready
+)
+
+// BroadcastSink fans out streamed items by sending a copy
+// of each item to all of its sinks.
+type BroadcastSink struct {
+	sinks      []api.Sink
+	policy     BackpressurePolicy
+	bufferSize int
+	input      <-chan any
+	logf       api.StreamLogFunc
+}
+
+// Broadcast creates a new *BroadcastSink that sends each
+// streamed item to all specified sinks.
+func Broadcast(targets ...api.Sink) *BroadcastSink {
+	return &BroadcastSink{
+		sinks:      targets,
+		bufferSize: 1024,
+		logf:       log.NoLogFunc,
+	}
+}
+
+// Backpressure sets the policy used when a sink falls behind.
+func (b *BroadcastSink) Backpressure(policy BackpressurePolicy) *BroadcastSink {
+	b.policy = policy
+	return b
+}
+
+// BufferSize sets the size of the channel buffer of each sink.
+// With BackpressureDrop, items are dropped for a sink once its buffer is full.
+func (b *BroadcastSink) BufferSize(size int) *BroadcastSink {
+	b.bufferSize = size
+	return b
+}
+
+// SetInput sets the channel input
+func (b *BroadcastSink) SetInput(in <-chan any) {
+	b.input = in
+}
+
+// SetLogFunc sets the logging function for the component
+// and all of its sinks.
+func (b *BroadcastSink) SetLogFunc(f api.StreamLogFunc) {
+	b.logf = f
+	for _, snk := range b.sinks {
+		snk.SetLogFunc(f)
+	}
+}
+
+// SetDeadLetterFunc sets the dead-letter function for all sinks
+// that can send items to the stream dead-letter output.
+func (b *BroadcastSink) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	for _, snk := range b.sinks {
+		if reporter, ok := snk.(api.DeadLetterReporter); ok {
+			reporter.SetDeadLetterFunc(f)
+		}
+	}
+}
+
+// Open opens all sinks and starts broadcasting items. The returned
+// channel receives the combined errors from all sinks once they are done.
+func (b *BroadcastSink) Open(ctx context.Context) <-chan error {
+	b.logf(ctx, log.LogInfo(
+		"Component starting",
+		slog.String("sink", "Broadcast"),
+		slog.Int("sinks", len(b.sinks)),
+	))
+
+	result := make(chan error)
+
+	if b.input == nil {
+		go func() { result <- api.ErrInputChannelUndefined }()
+		return result
+	}
+
+	if len(b.sinks) == 0 {
+		go func() { result <- api.ErrSinkEmpty }()
+		return result
+	}
+
+	if b.bufferSize < 0 {
+		b.bufferSize = 0
+	}
+
+	// a sink that fails stops the broadcast
+	bctx, cancel := context.WithCancel(ctx)
+
+	// setup and open each sink with its own channel
+	outputs := make([]chan any, len(b.sinks))
+	results := make([]<-chan error, len(b.sinks))
+	for i, snk := range b.sinks {
+		outputs[i] = make(chan any, b.bufferSize)
+		snk.SetInput(outputs[i])
+		results[i] = snk.Open(bctx)
+	}
+
+	// collect sink results
+	var wg sync.WaitGroup
+	errs := make([]error, len(b.sinks))
+	for i, res := range results {
+		wg.Add(1)
+		go func(i int, res <-chan error) {
+			defer wg.Done()
+			if errs[i] = <-res; errs[i] != nil {
+				cancel()
+			}
+		}(i, res)
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outputs {
+				close(out)
+			}
+			wg.Wait()
+			b.logf(ctx, log.LogInfo(
+				"Component closing",
+				slog.String("sink", "Broadcast"),
+			))
+			cancel()
+			result <- errors.Join(errs...)
+			close(result)
+		}()
+
+		for {
+			select {
+			case item, opened := <-b.input:
+				if !opened {
+					return
+				}
+				for i, out := range outputs {
+					if !b.send(bctx, i, out, item) {
+						return
+					}
+				}
+			case <-bctx.Done():
+				return
+			}
+		}
+	}()
+
+	return result
+}
+
+// send sends item to the sink output according to the backpressure policy.
+// It returns false if the context is done.
+func (b *BroadcastSink) send(ctx context.Context, idx int, out chan<- any, item any) bool {
+	if b.policy == BackpressureDrop {
+		select {
+		case out <- item:
+		case <-ctx.Done():
+			return false
+		default:
+			b.logf(ctx, log.LogDebug(
+				"Sink falling behind: item dropped",
+				slog.String("sink", "Broadcast"),
+				slog.Int("index", idx),
+			))
+		}
+		return true
+	}
+
+	select {
+	case out <- item:
+	case <-ctx.Done():
+		return false
+	}
+	return true
+}
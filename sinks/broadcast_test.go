@@ -0,0 +1,103 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+func TestBroadcastSink(t *testing.T) {
+	t.Run("broadcast to all sinks", func(t *testing.T) {
+		slice := Slice[string]()
+		var count atomic.Int32
+		fn := Func(func(string) error {
+			count.Add(1)
+			return nil
+		})
+
+		in := make(chan any)
+		go func() {
+			in <- "A"
+			in <- "B"
+			in <- "C"
+			close(in)
+		}()
+
+		b := Broadcast(slice, fn)
+		b.SetInput(in)
+
+		select {
+		case err := <-b.Open(context.TODO()):
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(slice.Get()) != 3 {
+				t.Fatal("unexpected slice length", len(slice.Get()))
+			}
+			if count.Load() != 3 {
+				t.Fatal("unexpected func count", count.Load())
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+	})
+
+	t.Run("drop items for slow sink", func(t *testing.T) {
+		fast := Slice[int]()
+		release := make(chan struct{})
+		var slowCount atomic.Int32
+		slow := Func(func(int) error {
+			<-release
+			slowCount.Add(1)
+			return nil
+		})
+
+		in := make(chan any)
+		go func() {
+			for i := 0; i < 100; i++ {
+				in <- i
+			}
+			close(release)
+			close(in)
+		}()
+
+		b := Broadcast(fast, slow).Backpressure(BackpressureDrop).BufferSize(2)
+		b.SetInput(in)
+
+		select {
+		case err := <-b.Open(context.TODO()):
+			if err != nil {
+				t.Fatal(err)
+			}
+			if slowCount.Load() >= 100 {
+				t.Fatal("expecting items dropped for slow sink, got", slowCount.Load())
+			}
+		case <-time.After(500 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+	})
+
+	t.Run("combine sink errors", func(t *testing.T) {
+		in := make(chan any)
+		close(in)
+
+		b := Broadcast(Func[string](nil), Slice[string](), Func[int](nil))
+		b.SetInput(in)
+
+		select {
+		case err := <-b.Open(context.TODO()):
+			if !errors.Is(err, api.ErrSinkDestinationUndefined) {
+				t.Fatal("unexpected error:", err)
+			}
+			if len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+				t.Fatal("expecting 2 combined errors, got:", err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+	})
+}
@@ -17,10 +17,11 @@ type CSVSink[IN []string] struct {
 	delimChar rune // delimiter character
 	headers   IN   // optional csv headers
 
-	input     <-chan any
-	snkWriter io.Writer
-	csvWriter *csv.Writer
-	logf      api.StreamLogFunc
+	input       <-chan any
+	snkWriter   io.Writer
+	csvWriter   *csv.Writer
+	logf        api.StreamLogFunc
+	deadLetterf api.DeadLetterFunc
 }
 
 // CSV creates a *CSVSource
@@ -54,6 +55,12 @@ func (c *CSVSink[IN]) SetLogFunc(f api.StreamLogFunc) {
 	c.logf = f
 }
 
+// SetDeadLetterFunc sets a function called to send
+// mistyped items to the stream dead-letter output
+func (c *CSVSink[IN]) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	c.deadLetterf = f
+}
+
 // init  initializes the components
 func (c *CSVSink[IN]) init(ctx context.Context) error {
 	if c.logf == nil {
@@ -127,6 +134,9 @@ func (c *CSVSink[IN]) Open(ctx context.Context) <-chan error {
 						slog.String("sink", "CSV"),
 						slog.String("type", fmt.Sprintf("%T", item)),
 					))
+					if c.deadLetterf != nil {
+						c.deadLetterf(ctx, api.UnexpectedType[IN]("CSV", item))
+					}
 					continue
 				}
 
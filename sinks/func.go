@@ -2,6 +2,7 @@ package sinks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -11,9 +12,11 @@ import (
 
 // FuncSink uses a function to collect streamed items of type T.
 type FuncSink[T any] struct {
-	input <-chan any
-	logf  api.StreamLogFunc
-	f     func(T) error
+	input       <-chan any
+	logf        api.StreamLogFunc
+	deadLetterf api.DeadLetterFunc
+	errPolicy   api.ErrorPolicy
+	f           func(T) error
 }
 
 // Func creates a new *FuncSink using the specified function
@@ -34,6 +37,21 @@ func (c *FuncSink[T]) SetLogFunc(f api.StreamLogFunc) {
 	c.logf = f
 }
 
+// SetDeadLetterFunc sets a function called to send items to the stream
+// dead-letter output when the error policy is api.ErrorPolicyDeadLetter
+func (c *FuncSink[T]) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	c.deadLetterf = f
+}
+
+// SetErrorPolicy sets how errors returned by, or panics recovered from, the
+// sink function are handled. The default, api.ErrorPolicySkip, logs the error
+// and drops the item. With api.ErrorPolicyFail, the sink stops and returns the
+// error. Sinks have no side outputs: api.ErrorPolicyReroute sends the item to
+// the stream dead-letter output like api.ErrorPolicyDeadLetter.
+func (c *FuncSink[T]) SetErrorPolicy(policy api.ErrorPolicy) {
+	c.errPolicy = policy
+}
+
 // Open is the starting point that starts the collector
 func (c *FuncSink[T]) Open(ctx context.Context) <-chan error {
 	c.logf(ctx, log.LogInfo(
@@ -79,14 +97,14 @@ func (c *FuncSink[T]) Open(ctx context.Context) <-chan error {
 						slog.String("sink", "Func"),
 						slog.String("type", fmt.Sprintf("%T", item)),
 					))
+					c.deadLetter(ctx, api.UnexpectedType[T]("Func", item))
 					continue
 				}
-				if err := c.f(itemVal); err != nil {
-					c.logf(ctx, log.LogDebug(
-						"Error: User function returned error",
-						slog.String("sink", "Func"),
-						slog.String("error", err.Error()),
-					))
+				if err := c.call(itemVal); err != nil {
+					if err := c.handleErr(ctx, itemVal, err); err != nil {
+						result <- err
+						return
+					}
 				}
 			case <-ctx.Done():
 				return
@@ -96,3 +114,54 @@ func (c *FuncSink[T]) Open(ctx context.Context) <-chan error {
 
 	return result
 }
+
+// call applies the sink function to item. A panic raised by the
+// function is recovered and returned as an *api.PanicError.
+func (c *FuncSink[T]) call(item T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.NewPanicError(r, item)
+		}
+	}()
+	return c.f(item)
+}
+
+// handleErr applies the sink's error policy to an error returned by the
+// sink function. It returns the error if the sink must stop.
+func (c *FuncSink[T]) handleErr(ctx context.Context, item T, err error) error {
+	var panicErr *api.PanicError
+	if errors.As(err, &panicErr) {
+		c.logf(ctx, log.LogError(
+			"Error: User function panicked",
+			slog.String("sink", "Func"),
+			slog.String("error", err.Error()),
+			slog.String("stack", string(panicErr.Stack)),
+		))
+	} else {
+		c.logf(ctx, log.LogDebug(
+			"Error: User function returned error",
+			slog.String("sink", "Func"),
+			slog.String("error", err.Error()),
+		))
+	}
+
+	switch c.errPolicy {
+	case api.ErrorPolicyFail:
+		return err
+	case api.ErrorPolicyReroute, api.ErrorPolicyDeadLetter:
+		c.deadLetter(ctx, api.DeadLetter{Node: "Func", Reason: "function error", Item: item, Err: err})
+	}
+	return nil
+}
+
+// deadLetter sends dl to the stream dead-letter output, if a dead-letter func is set
+func (c *FuncSink[T]) deadLetter(ctx context.Context, dl api.DeadLetter) {
+	if c.deadLetterf == nil {
+		c.logf(ctx, log.LogWarn(
+			"Dead-letter output not set: item dropped",
+			slog.String("sink", "Func"),
+		))
+		return
+	}
+	c.deadLetterf(ctx, dl)
+}
@@ -2,8 +2,11 @@ package sinks
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/vladimirvivien/automi/api"
 )
 
 func TestFuncSink(t *testing.T) {
@@ -46,3 +49,79 @@ func TestFuncSinkErr(t *testing.T) {
 		t.Fatal("Waited too long ...")
 	}
 }
+
+func TestFuncSinkPanic(t *testing.T) {
+	open := func(f *FuncSink[string]) error {
+		in := make(chan any)
+		go func() {
+			defer close(in)
+			for _, s := range []string{"A", "", "B"} {
+				select {
+				case in <- s:
+				case <-time.After(50 * time.Millisecond):
+					return
+				}
+			}
+		}()
+		f.SetInput(in)
+		select {
+		case err := <-f.Open(context.TODO()):
+			return err
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+		return nil
+	}
+	collect := func(items *[]string) func(string) error {
+		return func(val string) error {
+			if val == "" {
+				panic("empty value")
+			}
+			*items = append(*items, val)
+			return nil
+		}
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		var items []string
+		if err := open(Func(collect(&items))); err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 2 {
+			t.Fatal("unexpected items:", items)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		var items []string
+		f := Func(collect(&items))
+		f.SetErrorPolicy(api.ErrorPolicyFail)
+		err := open(f)
+		var panicErr *api.PanicError
+		if !errors.As(err, &panicErr) {
+			t.Fatalf("expecting *api.PanicError, got %v", err)
+		}
+		if panicErr.Item != "" || len(panicErr.Stack) == 0 {
+			t.Fatalf("unexpected panic error: %#v", panicErr)
+		}
+		if len(items) != 1 {
+			t.Fatal("unexpected items:", items)
+		}
+	})
+
+	t.Run("dead letter", func(t *testing.T) {
+		var items []string
+		var letters []api.DeadLetter
+		f := Func(collect(&items))
+		f.SetErrorPolicy(api.ErrorPolicyDeadLetter)
+		f.SetDeadLetterFunc(func(ctx context.Context, dl api.DeadLetter) {
+			letters = append(letters, dl)
+		})
+		if err := open(f); err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 2 || len(letters) != 1 || letters[0].Node != "Func" {
+			t.Fatalf("unexpected result: items %v, dead letters %#v", items, letters)
+		}
+	})
+}
@@ -11,9 +11,10 @@ import (
 
 // SliceSink collects streamed items into a slice
 type SliceSink[IN any, SLICE []IN] struct {
-	slice SLICE
-	input <-chan any
-	logf  api.StreamLogFunc
+	slice       SLICE
+	input       <-chan any
+	logf        api.StreamLogFunc
+	deadLetterf api.DeadLetterFunc
 }
 
 // Slice is the constructor function which returns a new
@@ -39,6 +40,12 @@ func (s *SliceSink[IN, SLICE]) SetLogFunc(f api.StreamLogFunc) {
 	s.logf = f
 }
 
+// SetDeadLetterFunc sets a function called to send
+// mistyped items to the stream dead-letter output
+func (s *SliceSink[IN, SLICE]) SetDeadLetterFunc(f api.DeadLetterFunc) {
+	s.deadLetterf = f
+}
+
 // Open starts the collector and returns and waits on the returned
 // channel for the collector to be done or an error to be received.
 func (s *SliceSink[IN, SLICE]) Open(ctx context.Context) <-chan error {
@@ -71,6 +78,9 @@ func (s *SliceSink[IN, SLICE]) Open(ctx context.Context) <-chan error {
 						slog.String("sink", "Slice"),
 						slog.String("type", fmt.Sprintf("%T", item)),
 					))
+					if s.deadLetterf != nil {
+						s.deadLetterf(ctx, api.UnexpectedType[IN]("Slice", item))
+					}
 					continue
 				}
 				s.slice = append(s.slice, data)
@@ -61,12 +61,16 @@ func (c *ChanSource[T]) Open(ctx context.Context) error {
 		}()
 
 		for {
-			val, open := <-c.channel
-			if !open {
-				return
-			}
 			select {
-			case c.output <- val:
+			case val, open := <-c.channel:
+				if !open {
+					return
+				}
+				select {
+				case c.output <- val:
+				case <-exeCtx.Done():
+					return
+				}
 			case <-exeCtx.Done():
 				return
 			}
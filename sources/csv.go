@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -22,6 +23,7 @@ type CSVSource[OUT []string] struct {
 	srcReader io.Reader
 	csvReader *csv.Reader
 	logf      api.StreamLogFunc
+	errf      api.StreamErrFunc
 	output    chan any
 }
 
@@ -65,6 +67,11 @@ func (c *CSVSource[OUT]) SetLogFunc(f api.StreamLogFunc) {
 	c.logf = f
 }
 
+// SetErrFunc sets a function to report runtime read errors to the stream
+func (c *CSVSource[OUT]) SetErrFunc(f api.StreamErrFunc) {
+	c.errf = f
+}
+
 // init internal initialization method
 func (c *CSVSource[OUT]) init(ctx context.Context) error {
 	c.logf(ctx, log.LogInfo(
@@ -128,7 +135,7 @@ func (c *CSVSource[OUT]) Open(ctx context.Context) (err error) {
 			close(c.output)
 		}()
 
-		for {
+		for index := int64(0); ; index++ {
 			row, err := c.csvReader.Read()
 			if err != nil {
 				if err == io.EOF {
@@ -139,7 +146,18 @@ func (c *CSVSource[OUT]) Open(ctx context.Context) (err error) {
 					slog.String("source", "CSV"),
 					slog.String("err", err.Error()),
 				))
-				continue
+				if c.errf != nil {
+					c.errf(ctx, api.ErrorWithItem(
+						fmt.Sprintf("csv: read row %d: %s", index, err),
+						api.StreamItem[OUT]{Index: index, Item: row},
+					))
+				}
+				// parse errors are recoverable, other read errors are not
+				var parseErr *csv.ParseError
+				if errors.As(err, &parseErr) {
+					continue
+				}
+				return
 			}
 
 			select {
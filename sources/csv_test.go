@@ -3,14 +3,17 @@ package sources
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/vladimirvivien/automi/api"
 	"github.com/vladimirvivien/automi/testutil"
 	"github.com/vladimirvivien/gexe"
 )
@@ -121,6 +124,49 @@ func TestCSVSourceWithFile(t *testing.T) {
 	m.RUnlock()
 }
 
+func TestCSVSourceReadErrors(t *testing.T) {
+	data := "Col1,Col2,Col3\nChristophe,Petion\nToussaint,Guerrier,Caiman\nDessaline,\"Cap\"Haitien\",Nord"
+	csv := CSV(strings.NewReader(data)).HasHeaders()
+
+	var m sync.Mutex
+	var reported []error
+	csv.SetErrFunc(func(ctx context.Context, err error) {
+		m.Lock()
+		reported = append(reported, err)
+		m.Unlock()
+	})
+
+	if err := csv.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for range csv.GetOutput() {
+		count++
+	}
+
+	if count != 2 {
+		t.Fatal("Expecting rowcount 2, got ", count)
+	}
+	m.Lock()
+	defer m.Unlock()
+	if len(reported) != 1 {
+		t.Fatal("Expecting 1 reported error, got ", reported)
+	}
+	var itemErr api.StreamError[[]string]
+	if !errors.As(reported[0], &itemErr) || itemErr.Item().Index != 0 {
+		t.Fatal("unexpected reported error: ", reported[0])
+	}
+}
+
+func TestCSVSourceHeaderError(t *testing.T) {
+	errRead := errors.New("read failed")
+	csv := CSV(iotest.ErrReader(errRead)).HasHeaders()
+	if err := csv.Open(context.Background()); !errors.Is(err, errRead) {
+		t.Fatal("expecting header read error, got ", err)
+	}
+}
+
 func Benchmark_CSV(b *testing.B) {
 	N := b.N
 	b.Logf("N = %d", N)
@@ -0,0 +1,143 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/log"
+)
+
+// MergeMode determines how a MergeSource combines the items of its sources.
+type MergeMode uint8
+
+const (
+	MergeInterleave MergeMode = iota // Emit items from all sources as they arrive (default)
+	MergeConcat                      // Emit all items from a source before moving to the next
+)
+
+// MergeSource combines the output of several sources into a single stream.
+// Its output is closed only after all of its sources are done.
+type MergeSource struct {
+	sources []api.Source
+	mode    MergeMode
+	output  chan any
+	logf    api.StreamLogFunc
+}
+
+// Merge creates a new *MergeSource that emits the items of all specified sources.
+func Merge(srcs ...api.Source) *MergeSource {
+	return &MergeSource{
+		sources: srcs,
+		output:  make(chan any, 1024),
+		logf:    log.NoLogFunc,
+	}
+}
+
+// Mode sets how items from the sources are combined (default MergeInterleave)
+func (m *MergeSource) Mode(mode MergeMode) *MergeSource {
+	m.mode = mode
+	return m
+}
+
+// GetOutput returns the output channel of this source node
+func (m *MergeSource) GetOutput() <-chan any {
+	return m.output
+}
+
+// SetLogFunc sets the logging function for the component
+// and all of its sources.
+func (m *MergeSource) SetLogFunc(f api.StreamLogFunc) {
+	m.logf = f
+	for _, src := range m.sources {
+		src.SetLogFunc(f)
+	}
+}
+
+// SetErrFunc sets the function, used to report runtime errors,
+// for all sources that can report errors.
+func (m *MergeSource) SetErrFunc(f api.StreamErrFunc) {
+	for _, src := range m.sources {
+		if reporter, ok := src.(api.ErrReporter); ok {
+			reporter.SetErrFunc(f)
+		}
+	}
+}
+
+// Open opens all sources and starts emitting their items
+func (m *MergeSource) Open(ctx context.Context) error {
+	if len(m.sources) == 0 {
+		m.logf(ctx, log.LogError(
+			"Source input missing",
+			slog.String("source", "Merge"),
+		))
+		return api.ErrSourceInputUndefined
+	}
+
+	m.logf(ctx, log.LogInfo(
+		"Component starting",
+		slog.String("source", "Merge"),
+		slog.Int("sources", len(m.sources)),
+	))
+
+	for i, src := range m.sources {
+		if err := src.Open(ctx); err != nil {
+			return fmt.Errorf("merge: source %d: %w", i, err)
+		}
+	}
+
+	go func() {
+		exeCtx, cancel := context.WithCancel(ctx)
+		defer func() {
+			m.logf(ctx, log.LogInfo(
+				"Component closing",
+				slog.String("source", "Merge"),
+			))
+			cancel()
+			close(m.output)
+		}()
+
+		if m.mode == MergeConcat {
+			for _, src := range m.sources {
+				if !m.forward(exeCtx, src.GetOutput()) {
+					return
+				}
+			}
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, src := range m.sources {
+			wg.Add(1)
+			go func(in <-chan any) {
+				defer wg.Done()
+				m.forward(exeCtx, in)
+			}(src.GetOutput())
+		}
+		wg.Wait()
+	}()
+
+	return nil
+}
+
+// forward sends all items from in to the output channel.
+// It returns false if the context is done before in is closed.
+func (m *MergeSource) forward(ctx context.Context, in <-chan any) bool {
+	for {
+		select {
+		case item, opened := <-in:
+			if !opened {
+				return true
+			}
+			select {
+			case m.output <- item:
+			case <-ctx.Done():
+				return false
+			}
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+func TestMergeSource(t *testing.T) {
+	collect := func(t *testing.T, src *MergeSource) []string {
+		if err := src.Open(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		var result []string
+		timeout := time.After(50 * time.Millisecond)
+		for {
+			select {
+			case item, opened := <-src.GetOutput():
+				if !opened {
+					return result
+				}
+				result = append(result, item.(string))
+			case <-timeout:
+				t.Fatal("waited too long")
+			}
+		}
+	}
+
+	t.Run("interleave", func(t *testing.T) {
+		ch := make(chan string)
+		go func() {
+			ch <- "D"
+			ch <- "E"
+			close(ch)
+		}()
+		src := Merge(Slice([]string{"A", "B", "C"}), Chan(ch), Slice([]string{"F"}))
+		result := collect(t, src)
+		slices.Sort(result)
+		if strings.Join(result, "") != "ABCDEF" {
+			t.Fatal("unexpected merged items:", result)
+		}
+	})
+
+	t.Run("concat", func(t *testing.T) {
+		ch := make(chan string)
+		go func() {
+			ch <- "D"
+			ch <- "E"
+			close(ch)
+		}()
+		src := Merge(Chan(ch), Slice([]string{"A", "B", "C"}), Slice([]string{"F"})).Mode(MergeConcat)
+		result := collect(t, src)
+		if strings.Join(result, "") != "DEABCF" {
+			t.Fatal("unexpected concatenated items:", result)
+		}
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		if err := Merge().Open(context.Background()); !errors.Is(err, api.ErrSourceInputUndefined) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		err := Merge(Slice([]string{"A"}), Chan[string](nil)).Open(context.Background())
+		if !errors.Is(err, api.ErrSourceInputUndefined) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+}
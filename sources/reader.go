@@ -2,6 +2,7 @@ package sources
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
 
@@ -15,6 +16,7 @@ type ReaderSource[OUT []byte] struct {
 	size   int
 	output chan any
 	logf   api.StreamLogFunc
+	errf   api.StreamErrFunc
 }
 
 // Reader creates a *ReaderEmitter source that can emit []bytes
@@ -44,6 +46,11 @@ func (e *ReaderSource[OUT]) SetLogFunc(f api.StreamLogFunc) {
 	e.logf = f
 }
 
+// SetErrFunc sets a function to report runtime read errors to the stream
+func (e *ReaderSource[OUT]) SetErrFunc(f api.StreamErrFunc) {
+	e.errf = f
+}
+
 // Open opens the emitter to start emitting data
 func (e *ReaderSource[OUT]) Open(ctx context.Context) error {
 	if err := e.setupReader(); err != nil {
@@ -67,7 +74,7 @@ func (e *ReaderSource[OUT]) Open(ctx context.Context) error {
 			close(e.output)
 		}()
 
-		for {
+		for index := int64(0); ; index++ {
 			buf := make([]byte, e.size)
 			bytesRead, err := e.reader.Read(buf)
 
@@ -85,6 +92,12 @@ func (e *ReaderSource[OUT]) Open(ctx context.Context) error {
 					slog.String("source", "io.Reader"),
 					slog.String("error", err.Error()),
 				))
+				if err != io.EOF && e.errf != nil {
+					e.errf(ctx, api.ErrorWithItem(
+						fmt.Sprintf("reader: read chunk %d: %s", index, err),
+						api.StreamItem[OUT]{Index: index},
+					))
+				}
 				return
 			}
 		}
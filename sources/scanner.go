@@ -3,6 +3,7 @@ package sources
 import (
 	"bufio"
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
 
@@ -18,6 +19,7 @@ type ScannerSource[OUT []byte] struct {
 	scanner    *bufio.Scanner
 	output     chan any
 	logf       api.StreamLogFunc
+	errf       api.StreamErrFunc
 }
 
 // Scanner returns a *ScannerEmitter that takes an io.Reader as its source.
@@ -41,6 +43,11 @@ func (e *ScannerSource[OUT]) SetLogFunc(f api.StreamLogFunc) {
 	e.logf = f
 }
 
+// SetErrFunc sets a function to report runtime read errors to the stream
+func (e *ScannerSource[OUT]) SetErrFunc(f api.StreamErrFunc) {
+	e.errf = f
+}
+
 // Open opens the emitter to start emitting data
 func (e *ScannerSource[OUT]) Open(ctx context.Context) error {
 	if err := e.setupScanner(); err != nil {
@@ -65,19 +72,29 @@ func (e *ScannerSource[OUT]) Open(ctx context.Context) error {
 			close(e.output)
 		}()
 
+		var index int64
 		for e.scanner.Scan() {
-			if err := e.scanner.Err(); err != nil {
-				e.logf(ctx, log.LogDebug(
-					"Error: reading source",
-					slog.String("source", "io.Scanner"),
-					slog.String("error", err.Error()),
-				))
-			}
 			select {
 			case e.output <- e.scanner.Bytes():
 			case <-exeCtx.Done():
 				return
 			}
+			index++
+		}
+
+		// scanner stops on first non-EOF error
+		if err := e.scanner.Err(); err != nil {
+			e.logf(ctx, log.LogDebug(
+				"Error: reading source",
+				slog.String("source", "io.Scanner"),
+				slog.String("error", err.Error()),
+			))
+			if e.errf != nil {
+				e.errf(ctx, api.ErrorWithItem(
+					fmt.Sprintf("scanner: read token %d: %s", index, err),
+					api.StreamItem[OUT]{Index: index},
+				))
+			}
 		}
 	}()
 	return nil
@@ -0,0 +1,78 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/log"
+)
+
+// sideSource is a source that merges the side output channels,
+// with the same name, from all operators of a parent stream.
+type sideSource struct {
+	name   string
+	inputs []<-chan any
+	output chan any
+	logf   api.StreamLogFunc
+}
+
+func newSideSource(name string) *sideSource {
+	return &sideSource{
+		name:   name,
+		output: make(chan any, 1024),
+		logf:   log.NoLogFunc,
+	}
+}
+
+// addInput adds a side output channel to be merged by the source
+func (s *sideSource) addInput(in <-chan any) {
+	s.inputs = append(s.inputs, in)
+}
+
+// GetOutput returns the output channel of the source
+func (s *sideSource) GetOutput() <-chan any {
+	return s.output
+}
+
+// SetLogFunc sets logging func for component
+func (s *sideSource) SetLogFunc(f api.StreamLogFunc) {
+	s.logf = f
+}
+
+// Open starts forwarding items from all side output channels.
+// The output is closed after all side output channels are closed.
+func (s *sideSource) Open(ctx context.Context) error {
+	s.logf(ctx, log.LogInfo(
+		"Component starting",
+		slog.String("source", "SideOutput"),
+		slog.String("route", s.name),
+	))
+
+	var wg sync.WaitGroup
+	for _, in := range s.inputs {
+		wg.Add(1)
+		go func(in <-chan any) {
+			defer wg.Done()
+			for item := range in {
+				select {
+				case s.output <- item:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(in)
+	}
+
+	go func() {
+		wg.Wait()
+		s.logf(ctx, log.LogInfo(
+			"Component closing",
+			slog.String("source", "SideOutput"),
+			slog.String("route", s.name),
+		))
+		close(s.output)
+	}()
+	return nil
+}
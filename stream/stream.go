@@ -2,11 +2,16 @@ package stream
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
 	"sync"
 
 	"github.com/vladimirvivien/automi/api"
 	"github.com/vladimirvivien/automi/log"
+	"github.com/vladimirvivien/automi/sinks"
+	"github.com/vladimirvivien/automi/sources"
 )
 
 // Stream represents a stream unto  which executor nodes can be
@@ -19,13 +24,39 @@ type Stream struct {
 	logChan     chan any
 	logSink     api.Sink
 	logSyncWait sync.WaitGroup
+	logMutex    sync.RWMutex
+	logClosed   bool
+	parent      *Stream
+	sides       []*Stream
+	errMutex    sync.Mutex
+	errs        []error
+	cancel      context.CancelFunc
+	stopSource  context.CancelFunc
+	failFast    bool
+
+	deadLetterChan   chan any
+	deadLetterSink   api.Sink
+	deadLetterDone   chan struct{} // closed once the dead-letter sink is done
+	deadLetterErr    error
+	deadLetterMutex  sync.RWMutex
+	deadLetterClosed bool
 }
 
-// From creates a new *Stream from specified api.Source
-func From(src api.Source) *Stream {
+// From creates a new *Stream from specified api.Source. When more than
+// one source is specified, their items are interleaved into the stream
+// using a sources.MergeSource. Use sources.Merge directly to concatenate
+// the sources instead.
+func From(srcs ...api.Source) *Stream {
 	s := &Stream{
-		source: src,
-		drain:  make(chan error),
+		drain: make(chan error),
+	}
+
+	switch len(srcs) {
+	case 0:
+	case 1:
+		s.source = srcs[0]
+	default:
+		s.source = sources.Merge(srcs...)
 	}
 
 	return s
@@ -40,16 +71,61 @@ func (s *Stream) WithLogSink(sink api.Sink) *Stream {
 	return s
 }
 
+// WithDeadLetterSink sets a sink that receives, as api.DeadLetter values,
+// items rejected by stream nodes. This includes items of unexpected types
+// and items handled with the api.ErrorPolicyDeadLetter error policy.
+// When no dead-letter sink is set, rejected items are logged and dropped.
+func (s *Stream) WithDeadLetterSink(sink api.Sink) *Stream {
+	if sink == nil {
+		return s
+	}
+	s.deadLetterChan = make(chan any, 1024)
+	s.deadLetterSink = sink
+	return s
+}
+
+// WithFailFast sets how the stream handles item-level errors, such as
+// source read errors or api.StreamError values, reported at runtime.
+// When true, the first error stops the stream. When false (default), the
+// stream continues and all errors are returned on the drain channel
+// once the stream is done.
+func (s *Stream) WithFailFast(failFast bool) *Stream {
+	s.failFast = failFast
+	return s
+}
+
 func (s *Stream) Run(nodes ...api.Operator) *Stream {
 	s.nodes = nodes
 	return s
 }
 
-func (s *Stream) Into(sink api.Sink) *Stream {
-	s.sink = sink
+// Into sets the sink(s) of the stream. When more than one sink is specified,
+// streamed items are broadcast to all of them using a sinks.BroadcastSink
+// that blocks on the slowest sink. Use sinks.Broadcast directly to configure
+// a different backpressure policy.
+func (s *Stream) Into(targets ...api.Sink) *Stream {
+	switch len(targets) {
+	case 0:
+		s.sink = nil
+	case 1:
+		s.sink = targets[0]
+	default:
+		s.sink = sinks.Broadcast(targets...)
+	}
 	return s
 }
 
+// SideOutput returns a new *Stream that receives items rerouted, by operators
+// of this stream, to the side output with the specified name. Operators and
+// a sink can be attached to the returned stream which is opened along with,
+// and completes before, its parent stream.
+func (s *Stream) SideOutput(name string) *Stream {
+	side := From(newSideSource(name))
+	side.parent = s
+	s.sides = append(s.sides, side)
+	return side
+}
+
 func (s *Stream) GetSource() api.Source {
 	return s.source
 }
@@ -79,45 +155,80 @@ func (s *Stream) Open(ctx context.Context) <-chan error {
 		return s.drain
 	}
 
+	// open dead-letter sink before nodes start rejecting items
+	if s.deadLetterSink != nil && s.deadLetterChan != nil {
+		s.deadLetterSink.SetLogFunc(s.Log)
+		s.deadLetterSink.SetInput(s.deadLetterChan)
+		result := s.deadLetterSink.Open(ctx)
+		s.deadLetterDone = make(chan struct{})
+		go func() {
+			s.deadLetterErr = <-result
+			close(s.deadLetterDone)
+		}()
+		s.Log(ctx, log.LogInfo("Initialized stream dead-letter channel"))
+	}
+
 	// open stream
 	go func() {
 		strmCtx, cancel := context.WithCancel(ctx)
+		// the source has its own context so that it can be stopped
+		// while in-flight items drain through the rest of the stream
+		srcCtx, stopSource := context.WithCancel(strmCtx)
+		s.setCancel(cancel, stopSource)
 		defer func() {
+			stopSource()
 			cancel()
 		}()
 
 		// open source, if err bail
-		if err := s.source.Open(strmCtx); err != nil {
-			//s.drainErr(err)
+		if err := s.source.Open(srcCtx); err != nil {
+			s.Log(ctx, log.LogError("Source failed to open", slog.String("error", err.Error())))
+			s.stopDeadLetters(ctx)
+			s.stopReporter(ctx)
+			s.drain <- err
 			return
 		}
 
 		//open all operators in graph, if err bail
 		for _, op := range s.nodes {
 			if err := op.Exec(strmCtx); err != nil {
-				s.drainErr(err)
+				s.Log(ctx, log.LogError("Operator failed to start", slog.String("error", err.Error())))
+				s.stopDeadLetters(ctx)
+				s.stopReporter(ctx)
+				s.drain <- err
 				return
 			}
 		}
 
+		// open side output streams
+		sideResult := s.openSideStreams(strmCtx)
+
 		// open stream sink and wait for completion
+		sinkResult := s.sink.Open(strmCtx)
 		select {
-		case err := <-s.sink.Open(strmCtx):
-			s.Log(ctx, log.LogInfo("Closing stream"))
-			if s.logChan != nil {
-				s.Log(ctx, log.LogInfo("Stopping stream reporter"))
-				go close(s.logChan)  // closing reporter chan (no logging after this)
-				s.logSyncWait.Wait() // wait for logging to stop
+		case err := <-sinkResult:
+			if err != nil {
+				// stop operators blocked on the failed sink so side streams can end
+				cancel()
 			}
+			// side stream nodes report errors until side streams are done
+			sideErr := <-sideResult
+			err = errors.Join(append(s.runtimeErrs(), err, sideErr, s.stopDeadLetters(ctx))...)
+			s.Log(ctx, log.LogInfo("Closing stream"))
+			s.stopReporter(ctx)
 			s.drain <- err
 		case <-strmCtx.Done():
 			s.Log(ctx, log.LogInfo("Canceling stream"))
-			if s.logChan != nil {
-				s.Log(ctx, log.LogInfo("Stopping stream reporter"))
-				go close(s.logChan)
-				s.logSyncWait.Wait()
+			// wait for the sinks to stop before the stream is done
+			sinkErr, sideErr := <-sinkResult, <-sideResult
+			s.stopDeadLetters(ctx)
+			s.stopReporter(ctx)
+			// report runtime errors that caused the cancellation
+			err := errors.Join(append(s.runtimeErrs(), sinkErr, sideErr)...)
+			if err == nil {
+				err = strmCtx.Err()
 			}
-			s.drain <- strmCtx.Err()
+			s.drain <- err
 		}
 	}()
 
@@ -131,19 +242,26 @@ func (s *Stream) initGraph(ctx context.Context) error {
 		s.Log(ctx, log.LogError("No source configured"))
 		return api.ErrStreamEmpty
 	}
+	// sources.MergeSource propagates the log and err funcs to each merged source
 	s.source.SetLogFunc(s.Log)
+	if reporter, ok := s.source.(api.ErrReporter); ok {
+		reporter.SetErrFunc(s.reportErr)
+	}
 
 	if s.sink == nil {
 		s.Log(ctx, log.LogError("No sink configured"))
 		return api.ErrSinkEmpty
 	}
 	//s.sink.SetLogFunc(s.Log)
+	if reporter, ok := s.sink.(api.DeadLetterReporter); ok {
+		reporter.SetDeadLetterFunc(s.deadLetter)
+	}
 
 	// if there are no ops, link source to sink
 	if len(s.nodes) == 0 && s.sink != nil {
 		s.Log(ctx, log.LogWarn("No operator nodes found: binding source to sink directly"))
 		s.sink.SetInput(s.source.GetOutput())
-		return nil
+		return s.bindSideOutputs(ctx)
 	}
 
 	// link operators
@@ -156,9 +274,71 @@ func (s *Stream) initGraph(ctx context.Context) error {
 		s.Log(ctx, log.LogInfo(fmt.Sprintf("Binding node %d --> sink", idx)))
 	}
 
+	// link side outputs
+	return s.bindSideOutputs(ctx)
+}
+
+// bindSideOutputs binds the named side outputs of operators to
+// their respective side stream then initializes the side stream graphs.
+func (s *Stream) bindSideOutputs(ctx context.Context) error {
+	for _, side := range s.sides {
+		src := side.source.(*sideSource)
+		for i, op := range s.nodes {
+			emitter, ok := op.(api.SideEmitter)
+			if !ok {
+				continue
+			}
+			src.addInput(emitter.GetSideOutput(src.name))
+			s.Log(ctx, log.LogInfo(fmt.Sprintf("Binding node %d --> side output %s", i, src.name)))
+		}
+		if err := side.initGraph(ctx); err != nil {
+			return fmt.Errorf("side output %s: %w", src.name, err)
+		}
+	}
 	return nil
 }
 
+// openSideStreams opens all side output streams and returns a channel
+// that receives the combined errors once all side stream sinks are done.
+func (s *Stream) openSideStreams(ctx context.Context) <-chan error {
+	result := make(chan error, 1)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	collect := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	for _, side := range s.sides {
+		if err := side.source.Open(ctx); err != nil {
+			collect(err)
+			continue
+		}
+		for _, op := range side.nodes {
+			if err := op.Exec(ctx); err != nil {
+				collect(err)
+			}
+		}
+		nested := side.openSideStreams(ctx)
+		sinkResult := side.sink.Open(ctx)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			collect(errors.Join(<-sinkResult, <-nested))
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		result <- errors.Join(errs...)
+	}()
+	return result
+}
+
 // bindOps binds operator channels
 func (s *Stream) bindOps(ctx context.Context) {
 	if s.nodes == nil {
@@ -176,6 +356,12 @@ func (s *Stream) bindOps(ctx context.Context) {
 
 		// set operator reporter channels
 		op.SetLogFunc(s.Log)
+		if reporter, ok := op.(api.ErrReporter); ok {
+			reporter.SetErrFunc(s.reportErr)
+		}
+		if reporter, ok := op.(api.DeadLetterReporter); ok {
+			reporter.SetDeadLetterFunc(s.deadLetter)
+		}
 	}
 }
 
@@ -183,8 +369,135 @@ func (s *Stream) drainErr(err error) {
 	go func() { s.drain <- err }()
 }
 
+// reportErr is called by stream nodes to report runtime errors:
+//
+//   - api.CancelStreamError: the source is stopped and the stream ends gracefully
+//     once in-flight items are drained.
+//   - api.StreamError: the item-level error is collected and returned on the
+//     drain channel when the stream is done, or stops the stream if fail-fast.
+//   - any other error stops the stream and is returned on the drain channel.
+func (s *Stream) reportErr(ctx context.Context, err error) {
+	if s.parent != nil {
+		s.parent.reportErr(ctx, err)
+		return
+	}
+
+	s.errMutex.Lock()
+	defer s.errMutex.Unlock()
+
+	switch {
+	case api.IsCancelStreamError(err):
+		s.Log(ctx, log.LogInfo("Stream cancellation requested", slog.String("reason", err.Error())))
+		if s.stopSource != nil {
+			s.stopSource()
+		}
+	case api.IsStreamError(err):
+		s.Log(ctx, log.LogWarn("Stream item error", slog.String("error", err.Error())))
+		s.errs = append(s.errs, err)
+		if s.failFast && s.cancel != nil {
+			s.cancel()
+		}
+	default:
+		s.Log(ctx, log.LogError("Stream runtime error", slog.String("error", err.Error())))
+		s.errs = append(s.errs, err)
+		if s.cancel != nil {
+			s.cancel()
+		}
+	}
+}
+
+// deadLetter is called by stream nodes to send rejected items
+// to the stream dead-letter sink, or to log them if none is set
+func (s *Stream) deadLetter(ctx context.Context, dl api.DeadLetter) {
+	if s.parent != nil {
+		s.parent.deadLetter(ctx, dl)
+		return
+	}
+
+	s.deadLetterMutex.RLock()
+	defer s.deadLetterMutex.RUnlock()
+	if s.deadLetterChan != nil && !s.deadLetterClosed {
+		select {
+		case s.deadLetterChan <- dl:
+			return
+		case <-s.deadLetterDone: // the dead-letter sink stopped reading
+		case <-ctx.Done():
+		}
+	}
+
+	attrs := []slog.Attr{
+		slog.String("node", dl.Node),
+		slog.String("reason", dl.Reason),
+		slog.String("type", fmt.Sprintf("%T", dl.Item)),
+	}
+	if dl.Expected != "" {
+		attrs = append(attrs, slog.String("expected", dl.Expected))
+	}
+	if dl.Err != nil {
+		attrs = append(attrs, slog.String("error", dl.Err.Error()))
+	}
+	s.Log(ctx, log.LogWarn("Item rejected: dead-letter sink not set", attrs...))
+}
+
+// runtimeErrs returns the errors collected from stream nodes
+func (s *Stream) runtimeErrs() []error {
+	s.errMutex.Lock()
+	defer s.errMutex.Unlock()
+	return slices.Clone(s.errs)
+}
+
+func (s *Stream) setCancel(cancel, stopSource context.CancelFunc) {
+	s.errMutex.Lock()
+	defer s.errMutex.Unlock()
+	s.cancel = cancel
+	s.stopSource = stopSource
+}
+
+// stopDeadLetters closes the dead-letter channel and waits for the
+// dead-letter sink to be done. Items rejected after this point are logged.
+func (s *Stream) stopDeadLetters(ctx context.Context) error {
+	if s.deadLetterChan == nil || s.deadLetterDone == nil {
+		return nil
+	}
+	s.Log(ctx, log.LogInfo("Stopping stream dead-letter sink"))
+
+	s.deadLetterMutex.Lock()
+	s.deadLetterClosed = true
+	close(s.deadLetterChan)
+	s.deadLetterMutex.Unlock()
+
+	<-s.deadLetterDone
+	return s.deadLetterErr
+}
+
+// stopReporter closes the reporter channel and waits for the log sink
+// to be done. Nodes still running can no longer log after this point.
+func (s *Stream) stopReporter(ctx context.Context) {
+	if s.logChan == nil {
+		return
+	}
+	s.Log(ctx, log.LogInfo("Stopping stream reporter"))
+
+	s.logMutex.Lock()
+	s.logClosed = true
+	close(s.logChan) // closing reporter chan (no logging after this)
+	s.logMutex.Unlock()
+
+	s.logSyncWait.Wait() // wait for logging to stop
+}
+
 // Log sends an api.StreamLog to the stream reporter channel
 func (s *Stream) Log(ctx context.Context, log api.StreamLog) {
+	if s.parent != nil {
+		s.parent.Log(ctx, log)
+		return
+	}
+
+	s.logMutex.RLock()
+	defer s.logMutex.RUnlock()
+	if s.logClosed {
+		return
+	}
 	if s.logSink != nil && s.logChan != nil {
 		select {
 		case s.logChan <- log:
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -187,3 +188,230 @@ func TestStreamSynchronization(t *testing.T) {
 		// TODO: See long-running synchronization task
 	})
 }
+
+func TestStreamWithErrorPolicy(t *testing.T) {
+	errLower := errors.New("lowercase word")
+	upper := func(ctx context.Context, in string) (string, error) {
+		if strings.ToLower(in) == in {
+			return "", errLower
+		}
+		return in, nil
+	}
+
+	t.Run("fail policy stops stream", func(t *testing.T) {
+		src := sources.Slice([]string{"HELLO", "world", "HOW", "ARE", "YOU"})
+		strm := From(src).Run(exec.MapWithErr(upper, api.ErrorPolicyFail))
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.Into(sinks.Discard())
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if !errors.Is(err, errLower) {
+				t.Fatal("expecting stream error, got:", err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+	})
+
+	t.Run("reroute policy sends to error output", func(t *testing.T) {
+		src := sources.Slice([]string{"HELLO", "world", "HOW", "are", "YOU"})
+		main := sinks.Slice[string]()
+		failed := sinks.Slice[api.StreamResult]()
+		strm := From(src).Run(exec.MapWithErr(upper, api.ErrorPolicyReroute))
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.SideOutput(api.ErrorOutput).Into(failed)
+		strm.Into(main)
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+
+		if len(main.Get()) != 3 {
+			t.Fatal("unexpected main stream data:", main.Get())
+		}
+		if len(failed.Get()) != 2 {
+			t.Fatal("unexpected error output data:", failed.Get())
+		}
+		for _, result := range failed.Get() {
+			if !errors.Is(result.Err, errLower) {
+				t.Fatal("unexpected error:", result.Err)
+			}
+		}
+	})
+
+	t.Run("fail policy waits for sink", func(t *testing.T) {
+		words := make([]string, 100)
+		for i := range words {
+			words[i] = "WORD"
+		}
+		words[10] = "word"
+		op := exec.MapWithErr(upper, api.ErrorPolicyFail)
+		op.SetConcurrency(4)
+
+		var done atomic.Bool
+		strm := From(sources.Slice(words)).Run(op)
+		strm.Into(sinks.Func(func(string) error {
+			time.Sleep(5 * time.Millisecond)
+			if done.Load() {
+				t.Error("sink still running after the stream is done")
+			}
+			return nil
+		}))
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			done.Store(true)
+			if !errors.Is(err, errLower) {
+				t.Fatal("expecting stream error, got:", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Waited too long ...")
+		}
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("recovered panic fails stream", func(t *testing.T) {
+		src := sources.Slice([]string{"HELLO", "world", "HOW"})
+		op := exec.Map(func(ctx context.Context, in string) string {
+			if strings.ToLower(in) == in {
+				panic("lowercase word")
+			}
+			return in
+		})
+		op.SetErrorPolicy(api.ErrorPolicyFail)
+		strm := From(src).Run(op)
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.Into(sinks.Discard())
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			var panicErr *api.PanicError
+			if !errors.As(err, &panicErr) {
+				t.Fatal("expecting panic error, got:", err)
+			}
+			if panicErr.Item != "world" {
+				t.Fatal("unexpected panic item:", panicErr.Item)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+	})
+}
+
+func TestStreamErrors(t *testing.T) {
+	t.Run("cancellation ends stream gracefully", func(t *testing.T) {
+		ctx, stop := context.WithCancel(context.Background())
+		defer stop()
+
+		// unbounded source
+		ch := make(chan int)
+		go func() {
+			for i := 0; ; i++ {
+				select {
+				case ch <- i:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		sink := sinks.Slice[int]()
+		strm := From(sources.Chan(ch)).Run(
+			exec.MapWithErr(func(ctx context.Context, in int) (int, error) {
+				if in == 10 {
+					return 0, api.CancellationError("enough items")
+				}
+				return in, nil
+			}, api.ErrorPolicyFail),
+		)
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.Into(sink)
+
+		select {
+		case err := <-strm.Open(ctx):
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+
+		items := sink.Get()
+		if len(items) < 10 {
+			t.Fatal("expecting in-flight items to drain, got", len(items))
+		}
+		for i := 0; i < 10; i++ {
+			if items[i] != i {
+				t.Fatal("unexpected items:", items[:10])
+			}
+		}
+	})
+
+	t.Run("cancellation returned as value", func(t *testing.T) {
+		strm := From(sources.Slice([]string{"A", "B", "STOP", "C"})).Run(
+			exec.Execute(func(ctx context.Context, in string) any {
+				if in == "STOP" {
+					return api.CancellationError("stop requested")
+				}
+				return in
+			}),
+		)
+		strm.Into(sinks.Discard())
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+	})
+
+	t.Run("item errors returned when stream is done", func(t *testing.T) {
+		sink := sinks.Slice[string]()
+		strm := From(sources.Slice([]string{"HELLO", "world", "HOW", "are", "YOU"})).Run(
+			exec.Execute(func(ctx context.Context, in string) api.StreamItem[string] {
+				return api.StreamItem[string]{Item: in}
+			}),
+			exec.MapWithErr(func(ctx context.Context, in api.StreamItem[string]) (string, error) {
+				if strings.ToLower(in.Item) == in.Item {
+					return "", api.ErrorWithItem("lowercase word", in)
+				}
+				return in.Item, nil
+			}, api.ErrorPolicySkip),
+		)
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.Into(sink)
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err == nil {
+				t.Fatal("expecting item errors")
+			}
+			var failed []string
+			for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+				var itemErr api.StreamError[string]
+				if !errors.As(e, &itemErr) {
+					t.Fatal("unexpected error type:", e)
+				}
+				failed = append(failed, itemErr.Item().Item)
+			}
+			if strings.Join(failed, " ") != "world are" {
+				t.Fatal("unexpected failed items:", failed)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Waited too long ...")
+		}
+
+		if len(sink.Get()) != 3 {
+			t.Fatal("unexpected stream data:", sink.Get())
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/operators/exec"
+	"github.com/vladimirvivien/automi/sinks"
+	"github.com/vladimirvivien/automi/sources"
+	"github.com/vladimirvivien/automi/testutil"
+)
+
+func TestStreamSideOutput(t *testing.T) {
+	t.Run("side output with operators", func(t *testing.T) {
+		src := sources.Slice([]string{"HELLO", "hi", "WORLD", "bye", "AUTOMI"})
+		main := sinks.Slice[string]()
+		invalid := sinks.Slice[string]()
+
+		strm := From(src).Run(
+			exec.Execute(func(ctx context.Context, in string) api.StreamResult {
+				if strings.ToUpper(in) != in {
+					return api.StreamResult{Value: in, Action: api.ActionRerouteItem, Route: "invalid"}
+				}
+				return api.StreamResult{Value: in}
+			}),
+		)
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.SideOutput("invalid").Run(
+			exec.Map(func(ctx context.Context, in string) string {
+				return "invalid:" + in
+			}),
+		).Into(invalid)
+		strm.Into(main)
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+
+		if strings.Join(main.Get(), " ") != "HELLO WORLD AUTOMI" {
+			t.Fatal("unexpected main stream data:", main.Get())
+		}
+		if strings.Join(invalid.Get(), " ") != "invalid:hi invalid:bye" {
+			t.Fatal("unexpected side stream data:", invalid.Get())
+		}
+	})
+
+	t.Run("side outputs from multiple operators", func(t *testing.T) {
+		src := sources.Slice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+		late := sinks.Slice[int]()
+
+		strm := From(src).Run(
+			exec.Execute(func(ctx context.Context, in int) api.StreamResult {
+				if in%5 == 0 {
+					return api.StreamResult{Value: in, Action: api.ActionRerouteItem, Route: "late"}
+				}
+				return api.StreamResult{Value: in}
+			}),
+			exec.Execute(func(ctx context.Context, in int) api.StreamResult {
+				if in%3 == 0 {
+					return api.StreamResult{Value: in, Action: api.ActionRerouteItem, Route: "late"}
+				}
+				return api.StreamResult{Value: in}
+			}),
+		)
+		strm.SideOutput("late").Into(late)
+		strm.Into(sinks.Discard())
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+
+		if len(late.Get()) != 5 {
+			t.Fatal("unexpected side output count:", late.Get())
+		}
+	})
+
+	t.Run("side stream errors are returned", func(t *testing.T) {
+		src := sources.Slice([]string{"HELLO", "hi", "WORLD"})
+		strm := From(src).Run(
+			exec.Execute(func(ctx context.Context, in string) api.StreamResult {
+				if strings.ToUpper(in) != in {
+					return api.StreamResult{Value: in, Action: api.ActionRerouteItem, Route: "invalid"}
+				}
+				return api.StreamResult{Value: in}
+			}),
+		)
+		strm.SideOutput("invalid").Run(
+			exec.MapWithErr(func(ctx context.Context, in string) (string, error) {
+				time.Sleep(10 * time.Millisecond) // end after the main stream
+				return "", errors.New("invalid item: " + in)
+			}, api.ErrorPolicyFail),
+		).Into(sinks.Discard())
+		strm.Into(sinks.Discard())
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err == nil || !strings.Contains(err.Error(), "invalid item: hi") {
+				t.Fatal("expecting side stream error, got:", err)
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+	})
+
+	t.Run("side output without sink", func(t *testing.T) {
+		strm := From(sources.Slice([]string{"hello"}))
+		strm.SideOutput("invalid")
+		strm.Into(sinks.Discard())
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err == nil {
+				t.Fatal("expecting error for side output without sink")
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+	})
+}
+
+func TestStreamSideOutput_SinkFails(t *testing.T) {
+	items := make([]int, 5000)
+	for i := range items {
+		items[i] = i
+	}
+	strm := From(sources.Slice(items)).Run(
+		exec.Execute(func(ctx context.Context, in int) api.StreamResult {
+			if in%2 == 0 {
+				return api.StreamResult{Value: in, Action: api.ActionRerouteItem, Route: "x"}
+			}
+			return api.StreamResult{Value: in}
+		}),
+	)
+	strm.SideOutput("x").Into(sinks.Discard())
+	failing := sinks.Func(func(int) error { return errors.New("sink failed") })
+	failing.SetErrorPolicy(api.ErrorPolicyFail)
+	strm.Into(failing)
+
+	select {
+	case err := <-strm.Open(context.Background()):
+		if err == nil || !strings.Contains(err.Error(), "sink failed") {
+			t.Fatal("expecting sink error, got:", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Took too long")
+	}
+}
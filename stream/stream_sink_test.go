@@ -3,11 +3,15 @@ package stream
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/vladimirvivien/automi/api"
+	"github.com/vladimirvivien/automi/operators/exec"
 	"github.com/vladimirvivien/automi/sinks"
 	"github.com/vladimirvivien/automi/sources"
 	"github.com/vladimirvivien/automi/testutil"
@@ -136,3 +140,134 @@ func TestStreamToSlice(t *testing.T) {
 		t.Fatal("Took too long")
 	}
 }
+
+func TestStreamToMultipleSinks(t *testing.T) {
+	src := sources.Slice([][]string{
+		{"request", "/i/a", "00:11:51:AA", "accepted"},
+		{"response", "/i/a/", "00:11:51:AA", "served"},
+		{"request", "/i/b", "00:11:22:33", "accepted"},
+	})
+
+	csv := new(bytes.Buffer)
+	slice := sinks.Slice[[]string]()
+	var count atomic.Int32
+
+	strm := From(src)
+	strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+	strm.Into(
+		sinks.CSV(csv),
+		slice,
+		sinks.Func(func(item []string) error {
+			count.Add(1)
+			return nil
+		}),
+	)
+
+	select {
+	case err := <-strm.Open(context.Background()):
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+		if len(lines) != 3 {
+			t.Error("unexpected csv sink data: want 3 lines, got", len(lines))
+		}
+		if len(slice.Get()) != 3 {
+			t.Error("unexpected slice sink data: want 3 items, got", len(slice.Get()))
+		}
+		if count.Load() != 3 {
+			t.Error("unexpected func sink data: want 3 items, got", count.Load())
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("Took too long")
+	}
+}
+
+func TestStreamWithDeadLetterSink(t *testing.T) {
+	src := sources.Slice([]any{1, "two", 3, 4.0, 4, 6})
+	half := exec.MapWithErr(func(ctx context.Context, in int) (int, error) {
+		if in%2 != 0 {
+			return 0, errors.New("odd number")
+		}
+		return in / 2, nil
+	}, api.ErrorPolicyDeadLetter)
+	toStr := exec.Map(func(ctx context.Context, in int) any {
+		if in == 3 {
+			return in // rejected by sink
+		}
+		return fmt.Sprint(in)
+	})
+	main := sinks.Slice[string]()
+	dead := sinks.Slice[api.DeadLetter]()
+
+	strm := From(src).Run(half, toStr).Into(main)
+	strm.WithDeadLetterSink(dead)
+	strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+
+	select {
+	case err := <-strm.Open(context.Background()):
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("Waited too long ...")
+	}
+
+	if strings.Join(main.Get(), " ") != "2" {
+		t.Fatal("unexpected main stream data:", main.Get())
+	}
+
+	letters := dead.Get()
+	if len(letters) != 5 {
+		t.Fatalf("unexpected dead letter count %d: %#v", len(letters), letters)
+	}
+	var mistyped, failed int
+	for _, dl := range letters {
+		switch dl.Reason {
+		case "unexpected type":
+			mistyped++
+			if dl.Item == 3 {
+				if dl.Node != "Slice" || dl.Expected != "string" || dl.Actual != "int" {
+					t.Fatalf("unexpected sink dead letter: %#v", dl)
+				}
+				continue
+			}
+			if dl.Node != "Exec" || dl.Expected != "int" || dl.Actual != fmt.Sprintf("%T", dl.Item) {
+				t.Fatalf("unexpected operator dead letter: %#v", dl)
+			}
+		case "function error":
+			failed++
+			if dl.Err == nil || (dl.Item != 1 && dl.Item != 3) {
+				t.Fatalf("unexpected dead letter: %#v", dl)
+			}
+		default:
+			t.Fatalf("unexpected dead letter: %#v", dl)
+		}
+	}
+	if mistyped != 3 || failed != 2 {
+		t.Fatalf("unexpected dead letters: %#v", letters)
+	}
+}
+
+func TestStreamWithDeadLetterSink_SinkFails(t *testing.T) {
+	items := make([]int, 5000)
+	errStore := errors.New("dead-letter store unavailable")
+	dead := sinks.Func(func(api.DeadLetter) error { return errStore })
+	dead.SetErrorPolicy(api.ErrorPolicyFail)
+
+	strm := From(sources.Slice(items)).Run(
+		exec.MapWithErr(func(ctx context.Context, in int) (int, error) {
+			return 0, errors.New("rejected")
+		}, api.ErrorPolicyDeadLetter),
+	).Into(sinks.Discard())
+	strm.WithDeadLetterSink(dead)
+
+	select {
+	case err := <-strm.Open(context.Background()):
+		if !errors.Is(err, errStore) {
+			t.Fatal("expecting dead-letter sink error, got:", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Waited too long ...")
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"path/filepath"
 	"strings"
 	"sync/atomic"
 	"testing"
+	"testing/iotest"
 	"time"
 
+	"github.com/vladimirvivien/automi/api"
 	"github.com/vladimirvivien/automi/sinks"
 	"github.com/vladimirvivien/automi/sources"
 	"github.com/vladimirvivien/automi/testutil"
@@ -183,3 +186,119 @@ func TestStreamFromScanner(t *testing.T) {
 		t.Fatal("Took too long")
 	}
 }
+
+func TestStreamFromMultipleSources(t *testing.T) {
+	t.Run("interleaved", func(t *testing.T) {
+		ch := make(chan []string)
+		go func() {
+			ch <- []string{"request", "/i/c", "00:11:51:AA", "accepted"}
+			close(ch)
+		}()
+
+		csv := sources.CSV(strings.NewReader(`"request", "/i/a", "00:11:51:AA", "accepted"
+"response", "/i/a/", "00:11:51:AA", "served"`))
+
+		var count atomic.Int32
+		strm := From(csv, sources.Chan(ch), sources.Slice([][]string{{"response", "/i/c", "00:11:51:AA", "served"}}))
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.Into(sinks.Func(func(items []string) error {
+			count.Add(1)
+			return nil
+		}))
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count.Load() != 4 {
+				t.Fatalf("expecting %d items, got %d", 4, count.Load())
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+	})
+
+	t.Run("concatenated", func(t *testing.T) {
+		sink := sinks.Slice[string]()
+		strm := From(sources.Merge(
+			sources.Slice([]string{"A", "B"}),
+			sources.Slice([]string{"C", "D"}),
+		).Mode(sources.MergeConcat))
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.Into(sink)
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(sink.Get(), "") != "ABCD" {
+				t.Fatal("unexpected stream data:", sink.Get())
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+	})
+}
+
+func TestStreamSourceErrors(t *testing.T) {
+	t.Run("source open error", func(t *testing.T) {
+		errRead := errors.New("header read failed")
+		strm := From(sources.CSV(iotest.ErrReader(errRead)).HasHeaders())
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.Into(sinks.Discard())
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if !errors.Is(err, errRead) {
+				t.Fatal("expecting source error, got:", err)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+	})
+
+	data := "Col1,Col2,Col3\na,b\nc,d,e\nf\ng,h,i\n"
+
+	t.Run("source read errors with continue", func(t *testing.T) {
+		var count atomic.Int32
+		strm := From(sources.CSV(strings.NewReader(data)).HasHeaders())
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.Into(sinks.Func(func(items []string) error {
+			count.Add(1)
+			return nil
+		}))
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if err == nil {
+				t.Fatal("expecting read errors")
+			}
+			if len(err.(interface{ Unwrap() []error }).Unwrap()) != 2 {
+				t.Fatal("expecting 2 read errors, got:", err)
+			}
+			if count.Load() != 2 {
+				t.Fatalf("expecting %d rows, got %d", 2, count.Load())
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+	})
+
+	t.Run("source read errors with fail-fast", func(t *testing.T) {
+		strm := From(sources.CSV(strings.NewReader(data)).HasHeaders())
+		strm.WithLogSink(sinks.Func(testutil.LogSinkFunc(t)))
+		strm.WithFailFast(true)
+		strm.Into(sinks.Discard())
+
+		select {
+		case err := <-strm.Open(context.Background()):
+			if !api.IsStreamError(err) {
+				t.Fatal("expecting read error, got:", err)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("Took too long")
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package testutil
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+// FakeClock is an api.Clock whose time only moves when advanced. Items sent
+// with Send are timestamped by the operator before Send returns, so that the
+// clock can be advanced without racing with the operator.
+type FakeClock struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	reads   uint64 // number of Now calls
+	tickers []*fakeTicker
+}
+
+// NewFakeClock returns a FakeClock set to 2024-01-01 00:00:00 UTC
+func NewFakeClock() *FakeClock {
+	c := &FakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.cond = sync.NewCond(&c.mutex)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reads++
+	c.cond.Broadcast()
+	return c.now
+}
+
+func (c *FakeClock) NewTicker(d time.Duration) api.Ticker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	t := &fakeTicker{clock: c, c: make(chan time.Time), interval: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// Send sends item to the input of an operator and waits until the operator
+// reads the clock to timestamp the item. The operator must read the clock
+// once per item it receives, and only from the goroutine receiving items.
+func (c *FakeClock) Send(in chan<- any, item any) {
+	c.mutex.Lock()
+	reads := c.reads
+	c.mutex.Unlock()
+
+	in <- item
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.reads == reads {
+		c.cond.Wait()
+	}
+}
+
+// Advance moves the clock forward and delivers, in order, the ticks that are
+// due. Each tick is delivered synchronously: Advance returns once all ticks
+// have been received.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	end := c.now.Add(d)
+	c.mutex.Unlock()
+
+	for {
+		c.mutex.Lock()
+		var due *fakeTicker
+		for _, t := range c.tickers {
+			if !t.stopped && !t.next.After(end) && (due == nil || t.next.Before(due.next)) {
+				due = t
+			}
+		}
+		if due == nil {
+			c.now = end
+			c.mutex.Unlock()
+			return
+		}
+		at := due.next
+		c.now = at
+		due.next = at.Add(due.interval)
+		c.mutex.Unlock()
+
+		due.c <- at
+	}
+}
+
+type fakeTicker struct {
+	clock    *FakeClock
+	c        chan time.Time
+	interval time.Duration
+	next     time.Time
+	stopped  bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	t.stopped = true
+}
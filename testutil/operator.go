@@ -0,0 +1,55 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/vladimirvivien/automi/api"
+)
+
+// OperatorTest is a table-driven test of an operator node: items sent by
+// Send are fed to Operator, whose input is closed once Send returns, and
+// Tester receives the operator output. Send runs in its own goroutine.
+type OperatorTest struct {
+	Operator api.Operator
+	Send     func(in chan<- any)
+	Tester   func(t *testing.T, out <-chan any)
+}
+
+// RunOperatorTest starts the operator of test then feeds it. It fails the
+// test if the tester does not return within 100ms.
+func RunOperatorTest(t *testing.T, test OperatorTest) {
+	t.Helper()
+	in := make(chan any)
+	test.Operator.SetInput(in)
+
+	wait := make(chan struct{})
+	go func() {
+		defer close(wait)
+		test.Tester(t, test.Operator.GetOutput())
+	}()
+
+	if err := test.Operator.Exec(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		test.Send(in)
+		close(in)
+	}()
+
+	select {
+	case <-wait:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Took too long...")
+	}
+}
+
+// SendItems returns a Send function of OperatorTest that sends items in order
+func SendItems[T any](items ...T) func(chan<- any) {
+	return func(in chan<- any) {
+		for _, item := range items {
+			in <- item
+		}
+	}
+}